@@ -0,0 +1,321 @@
+// Package analytics calculates the common board game statistics from the plays, all the
+// aggregates are weighted by the play quantity
+package analytics
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// Coins is the count of the games by the number of plays, the buckets are cumulative, so a dime
+// is a nickel too
+type Coins struct {
+	// Nickels are the games with 5 or more plays
+	Nickels int `json:"nickels"`
+	// Dimes are the games with 10 or more plays
+	Dimes int `json:"dimes"`
+	// Quarters are the games with 25 or more plays
+	Quarters int `json:"quarters"`
+	// Dollars are the games with 100 or more plays
+	Dollars int `json:"dollars"`
+}
+
+// Streak is a run of consecutive days with at least one play
+type Streak struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Days  int       `json:"days"`
+}
+
+// GameStats is the statistics for a single game
+type GameStats struct {
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	Plays     float64       `json:"plays"`
+	Duration  time.Duration `json:"duration"`
+	FirstPlay time.Time     `json:"first_play"`
+	LastPlay  time.Time     `json:"last_play"`
+}
+
+// PlayerStats is the statistics for a single player, the player is identified by the BGG username
+// or the name if there is no username
+type PlayerStats struct {
+	Key          string  `json:"key"`
+	Name         string  `json:"name"`
+	UserName     string  `json:"user_name,omitempty"`
+	Plays        float64 `json:"plays"`
+	Wins         float64 `json:"wins"`
+	WinRate      float64 `json:"win_rate"`
+	ScoredPlays  float64 `json:"scored_plays"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// Report is all the statistics in one place
+type Report struct {
+	Plays         float64            `json:"plays"`
+	Hours         float64            `json:"hours"`
+	GameHIndex    int                `json:"game_h_index"`
+	PlayerHIndex  int                `json:"player_h_index"`
+	Coins         Coins              `json:"coins"`
+	LongestStreak Streak             `json:"longest_streak"`
+	LastStreak    Streak             `json:"last_streak"`
+	PerMonth      map[string]float64 `json:"per_month"`
+	PerWeekday    [7]float64         `json:"per_weekday"`
+	Games         []GameStats        `json:"games"`
+	Players       []PlayerStats      `json:"players"`
+}
+
+func quantity(p *gobgg.Play) float64 {
+	if p.Quantity <= 0 {
+		return 1
+	}
+
+	return p.Quantity
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// PlayerKey returns the key used to identify a player, it is the lower case username or
+// the name if there is no username
+func PlayerKey(p *gobgg.Player) string {
+	if p.UserName != "" {
+		return "u:" + strings.ToLower(p.UserName)
+	}
+
+	return "n:" + strings.ToLower(strings.TrimSpace(p.Name))
+}
+
+func hIndex(counts []float64) int {
+	slices.SortFunc(counts, func(a, b float64) int {
+		switch {
+		case a > b:
+			return -1
+		case a < b:
+			return 1
+		}
+		return 0
+	})
+
+	h := 0
+	for i := range counts {
+		if counts[i] < float64(i+1) {
+			break
+		}
+		h = i + 1
+	}
+
+	return h
+}
+
+// Games returns the per game statistics, sorted by the number of plays (descending)
+func Games(plays []gobgg.Play) []GameStats {
+	byID := make(map[int64]*GameStats)
+	var order []int64
+	for i := range plays {
+		p := &plays[i]
+		q := quantity(p)
+		gs, ok := byID[p.Item.ID]
+		if !ok {
+			gs = &GameStats{ID: p.Item.ID, Name: p.Item.Name}
+			byID[p.Item.ID] = gs
+			order = append(order, p.Item.ID)
+		}
+		gs.Plays += q
+		gs.Duration += time.Duration(float64(p.Length) * q)
+		if gs.FirstPlay.IsZero() || p.Date.Before(gs.FirstPlay) {
+			gs.FirstPlay = p.Date
+		}
+		if p.Date.After(gs.LastPlay) {
+			gs.LastPlay = p.Date
+		}
+	}
+
+	result := make([]GameStats, 0, len(order))
+	for _, id := range order {
+		result = append(result, *byID[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Plays > result[j].Plays
+	})
+
+	return result
+}
+
+// Players returns the per player statistics, sorted by the number of plays (descending).
+// The average score is only based on the plays with a numeric score
+func Players(plays []gobgg.Play) []PlayerStats {
+	byKey := make(map[string]*PlayerStats)
+	var order []string
+	for i := range plays {
+		p := &plays[i]
+		q := quantity(p)
+		for j := range p.Players {
+			player := &p.Players[j]
+			key := PlayerKey(player)
+			ps, ok := byKey[key]
+			if !ok {
+				ps = &PlayerStats{Key: key, Name: player.Name, UserName: player.UserName}
+				byKey[key] = ps
+				order = append(order, key)
+			}
+			ps.Plays += q
+			if player.Win {
+				ps.Wins += q
+			}
+			if score, ok := player.NumericScore(); ok {
+				ps.ScoredPlays += q
+				ps.AverageScore += score * q
+			}
+		}
+	}
+
+	result := make([]PlayerStats, 0, len(order))
+	for _, key := range order {
+		ps := byKey[key]
+		if ps.Plays > 0 {
+			ps.WinRate = ps.Wins / ps.Plays
+		}
+		if ps.ScoredPlays > 0 {
+			ps.AverageScore /= ps.ScoredPlays
+		}
+		result = append(result, *ps)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Plays > result[j].Plays
+	})
+
+	return result
+}
+
+// GameHIndex is the largest number h such that h games are played at least h times
+func GameHIndex(plays []gobgg.Play) int {
+	games := Games(plays)
+	counts := make([]float64, len(games))
+	for i := range games {
+		counts[i] = games[i].Plays
+	}
+
+	return hIndex(counts)
+}
+
+// PlayerHIndex is the largest number h such that h players are played with at least h times
+func PlayerHIndex(plays []gobgg.Play) int {
+	players := Players(plays)
+	counts := make([]float64, len(players))
+	for i := range players {
+		counts[i] = players[i].Plays
+	}
+
+	return hIndex(counts)
+}
+
+// CoinsCount returns the nickels, dimes, quarters and dollars count
+func CoinsCount(plays []gobgg.Play) Coins {
+	var c Coins
+	for _, g := range Games(plays) {
+		if g.Plays >= 100 {
+			c.Dollars++
+		}
+		if g.Plays >= 25 {
+			c.Quarters++
+		}
+		if g.Plays >= 10 {
+			c.Dimes++
+		}
+		if g.Plays >= 5 {
+			c.Nickels++
+		}
+	}
+
+	return c
+}
+
+// Streaks returns the longest streak and the streak that ends on the last play date
+func Streaks(plays []gobgg.Play) (longest Streak, last Streak) {
+	days := make([]time.Time, 0, len(plays))
+	for i := range plays {
+		if plays[i].Date.IsZero() {
+			continue
+		}
+		days = append(days, day(plays[i].Date))
+	}
+	if len(days) == 0 {
+		return
+	}
+
+	slices.SortFunc(days, func(a, b time.Time) int { return a.Compare(b) })
+	days = slices.Compact(days)
+
+	current := Streak{Start: days[0], End: days[0], Days: 1}
+	longest = current
+	for _, d := range days[1:] {
+		if d.Equal(current.End.AddDate(0, 0, 1)) {
+			current.End = d
+			current.Days++
+		} else {
+			current = Streak{Start: d, End: d, Days: 1}
+		}
+
+		if current.Days > longest.Days {
+			longest = current
+		}
+	}
+
+	return longest, current
+}
+
+// PerMonth returns the number of plays for each month, the key is in the YYYY-MM format
+func PerMonth(plays []gobgg.Play) map[string]float64 {
+	result := make(map[string]float64)
+	for i := range plays {
+		result[plays[i].Date.Format("2006-01")] += quantity(&plays[i])
+	}
+
+	return result
+}
+
+// PerWeekday returns the number of plays for each weekday, indexed by time.Weekday
+func PerWeekday(plays []gobgg.Play) [7]float64 {
+	var result [7]float64
+	for i := range plays {
+		result[plays[i].Date.Weekday()] += quantity(&plays[i])
+	}
+
+	return result
+}
+
+// TotalHours returns the total play time in hours
+func TotalHours(plays []gobgg.Play) float64 {
+	var total float64
+	for i := range plays {
+		total += plays[i].Length.Hours() * quantity(&plays[i])
+	}
+
+	return total
+}
+
+// Analyze returns the full report for the plays
+func Analyze(plays []gobgg.Play) *Report {
+	r := &Report{
+		Hours:        TotalHours(plays),
+		GameHIndex:   GameHIndex(plays),
+		PlayerHIndex: PlayerHIndex(plays),
+		Coins:        CoinsCount(plays),
+		PerMonth:     PerMonth(plays),
+		PerWeekday:   PerWeekday(plays),
+		Games:        Games(plays),
+		Players:      Players(plays),
+	}
+	r.LongestStreak, r.LastStreak = Streaks(plays)
+	for i := range plays {
+		r.Plays += quantity(&plays[i])
+	}
+
+	return r
+}
@@ -0,0 +1,83 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func play(date string, id int64, quantity float64, length time.Duration, players ...gobgg.Player) gobgg.Play {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		panic(err)
+	}
+
+	return gobgg.Play{
+		Date:     d,
+		Quantity: quantity,
+		Length:   length,
+		Item:     gobgg.Item{ID: id, Name: "game"},
+		Players:  players,
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	me := gobgg.Player{UserName: "Me", Name: "Me", Score: 10, Win: true}
+	meLost := gobgg.Player{UserName: "me", Name: "Me", Score: 4}
+	friend := gobgg.Player{Name: "Friend", Score: 6}
+	friendWon := gobgg.Player{Name: "friend ", Score: 12, Win: true}
+
+	plays := []gobgg.Play{
+		play("2024-01-01", 1, 10, time.Hour, me, friend),
+		play("2024-01-02", 2, 5, 30*time.Minute, meLost, friendWon),
+		play("2024-01-03", 3, 3, 0),
+		play("2024-01-05", 1, 100, 0),
+		play("2024-02-05", 4, 0, 2*time.Hour),
+		play("2024-02-06", 4, 2, 0),
+	}
+
+	r := Analyze(plays)
+	assert.Equal(t, 121.0, r.Plays)
+	// 10 * 1h + 5 * 0.5h + 1 * 2h
+	assert.Equal(t, 14.5, r.Hours)
+	// 110, 5, 3, 3
+	assert.Equal(t, 3, r.GameHIndex)
+	// Me and friend each 15 plays
+	assert.Equal(t, 2, r.PlayerHIndex)
+	// The dollar is a nickel, dime and quarter too
+	assert.Equal(t, Coins{Nickels: 2, Dimes: 1, Quarters: 1, Dollars: 1}, r.Coins)
+
+	assert.Equal(t, 3, r.LongestStreak.Days)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), r.LongestStreak.Start)
+	assert.Equal(t, 2, r.LastStreak.Days)
+	assert.Equal(t, time.Date(2024, 2, 6, 0, 0, 0, 0, time.UTC), r.LastStreak.End)
+
+	assert.Equal(t, map[string]float64{"2024-01": 118, "2024-02": 3}, r.PerMonth)
+	assert.Equal(t, 100.0, r.PerWeekday[time.Friday])
+	assert.Equal(t, 11.0, r.PerWeekday[time.Monday])
+
+	require.Len(t, r.Games, 4)
+	assert.Equal(t, int64(1), r.Games[0].ID)
+	assert.Equal(t, 110.0, r.Games[0].Plays)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), r.Games[0].FirstPlay)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), r.Games[0].LastPlay)
+
+	require.Len(t, r.Players, 2)
+	assert.Equal(t, "u:me", r.Players[0].Key)
+	assert.Equal(t, 15.0, r.Players[0].Plays)
+	assert.InDelta(t, 10.0/15, r.Players[0].WinRate, 1e-9)
+	assert.InDelta(t, (10*10+4*5)/15.0, r.Players[0].AverageScore, 1e-9)
+	assert.Equal(t, "n:friend", r.Players[1].Key)
+	assert.InDelta(t, 5.0/15, r.Players[1].WinRate, 1e-9)
+}
+
+func TestEmpty(t *testing.T) {
+	r := Analyze(nil)
+	assert.Zero(t, r.Plays)
+	assert.Zero(t, r.GameHIndex)
+	assert.Zero(t, r.LongestStreak.Days)
+	assert.Empty(t, r.Games)
+}
@@ -0,0 +1,320 @@
+package bggtest
+
+import (
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// The default fixtures, NewServer loads them unless the Empty option is used
+const (
+	// DemoUser is the user in the default fixtures
+	DemoUser = "gobgg"
+	// DemoPassword is the password of the DemoUser
+	DemoPassword = "secret"
+	// DemoGeekList is the id of the geek list in the default fixtures
+	DemoGeekList = 300000
+)
+
+func rank(kind gobgg.RankKind, id int64, name, friendly string, value int, bayes float64) gobgg.Rank {
+	return gobgg.Rank{
+		Kind:         kind,
+		ID:           id,
+		Name:         name,
+		FriendlyName: friendly,
+		Ranked:       value > 0,
+		Value:        value,
+		BayesAverage: bayes,
+	}
+}
+
+func links(designers []gobgg.Link, categories []gobgg.Link, families []gobgg.Link) map[string][]gobgg.Link {
+	return map[string][]gobgg.Link{
+		gobgg.BoardGameDesigner: designers,
+		gobgg.BoardGameCategory: categories,
+		gobgg.BoardGameFamily:   families,
+	}
+}
+
+func defaultGames() []*Game {
+	return []*Game{
+		{
+			ThingResult: gobgg.ThingResult{
+				ID:             342942,
+				Name:           "Ark Nova",
+				AlternateNames: []string{"アークノヴァ"},
+				Type:           gobgg.BoardGameType,
+				YearPublished:  2021,
+				Thumbnail:      "https://cf.geekdo-images.com/arknova__thumb/img/arknova.jpg",
+				Image:          "https://cf.geekdo-images.com/arknova__original/img/arknova.jpg",
+				MinPlayers:     1,
+				MaxPlayers:     4,
+				SuggestedPlayerCount: []gobgg.SuggestedPlayerCount{
+					{NumPlayers: "1", Best: 210, Recommended: 800, NotRecommended: 310},
+					{NumPlayers: "2", Best: 1200, Recommended: 400, NotRecommended: 40},
+					{NumPlayers: "3", Best: 500, Recommended: 900, NotRecommended: 120},
+					{NumPlayers: "4", Best: 120, Recommended: 700, NotRecommended: 600},
+				},
+				MinAge:        "14",
+				PlayTime:      "150",
+				MinPlayTime:   "90",
+				MaxPlayTime:   "150",
+				Description:   "Plan and build a modern, scientifically managed zoo.",
+				Links:         links([]gobgg.Link{{ID: 59855, Name: "Mathias Wigge"}}, []gobgg.Link{{ID: 1089, Name: "Animals"}}, nil),
+				UsersRated:    52000,
+				AverageRate:   8.54,
+				BayesAverage:  8.36,
+				UsersOwned:    80000,
+				UsersWishing:  20000,
+				NumWeight:     2100,
+				AverageWeight: 3.76,
+				Ranks: gobgg.Ranks{
+					rank(gobgg.RankKindSubtype, 1, "boardgame", "Board Game Rank", 3, 8.36),
+					rank(gobgg.RankKindFamily, 5497, "strategygames", "Strategy Game Rank", 2, 8.39),
+				},
+			},
+			BreakDown: gobgg.RankBreakDown{120, 90, 150, 300, 700, 1900, 5800, 12900, 16900, 13140},
+		},
+		{
+			ThingResult: gobgg.ThingResult{
+				ID:            174430,
+				Name:          "Gloomhaven",
+				Type:          gobgg.BoardGameType,
+				YearPublished: 2017,
+				Thumbnail:     "https://cf.geekdo-images.com/gloomhaven__thumb/img/gloomhaven.jpg",
+				Image:         "https://cf.geekdo-images.com/gloomhaven__original/img/gloomhaven.jpg",
+				MinPlayers:    1,
+				MaxPlayers:    4,
+				SuggestedPlayerCount: []gobgg.SuggestedPlayerCount{
+					{NumPlayers: "1", Best: 400, Recommended: 900, NotRecommended: 300},
+					{NumPlayers: "2", Best: 1100, Recommended: 700, NotRecommended: 60},
+					{NumPlayers: "3", Best: 1300, Recommended: 500, NotRecommended: 50},
+					{NumPlayers: "4", Best: 300, Recommended: 900, NotRecommended: 400},
+				},
+				MinAge:        "14",
+				PlayTime:      "120",
+				MinPlayTime:   "60",
+				MaxPlayTime:   "120",
+				Description:   "Vanquish monsters with strategic cardplay in a persistent legacy world.",
+				Links:         links([]gobgg.Link{{ID: 69802, Name: "Isaac Childres"}}, []gobgg.Link{{ID: 1022, Name: "Adventure"}}, []gobgg.Link{{ID: 37417, Name: "Series: Gloomhaven"}}),
+				UsersRated:    62000,
+				AverageRate:   8.58,
+				BayesAverage:  8.38,
+				UsersOwned:    100000,
+				UsersWishing:  18000,
+				NumWeight:     2700,
+				AverageWeight: 3.91,
+				Ranks: gobgg.Ranks{
+					rank(gobgg.RankKindSubtype, 1, "boardgame", "Board Game Rank", 2, 8.38),
+					rank(gobgg.RankKindFamily, 5497, "strategygames", "Strategy Game Rank", 3, 8.37),
+					rank(gobgg.RankKindFamily, 5496, "thematic", "Thematic Rank", 1, 8.45),
+				},
+			},
+			BreakDown: gobgg.RankBreakDown{900, 300, 400, 600, 1100, 2300, 5600, 12000, 17000, 21800},
+		},
+		{
+			ThingResult: gobgg.ThingResult{
+				ID:            161936,
+				Name:          "Pandemic Legacy: Season 1",
+				Type:          gobgg.BoardGameType,
+				YearPublished: 2015,
+				Thumbnail:     "https://cf.geekdo-images.com/pandemic__thumb/img/pandemic.jpg",
+				Image:         "https://cf.geekdo-images.com/pandemic__original/img/pandemic.jpg",
+				MinPlayers:    2,
+				MaxPlayers:    4,
+				SuggestedPlayerCount: []gobgg.SuggestedPlayerCount{
+					{NumPlayers: "2", Best: 900, Recommended: 700, NotRecommended: 50},
+					{NumPlayers: "3", Best: 600, Recommended: 900, NotRecommended: 100},
+					{NumPlayers: "4", Best: 800, Recommended: 700, NotRecommended: 120},
+				},
+				MinAge:        "13",
+				PlayTime:      "60",
+				MinPlayTime:   "60",
+				MaxPlayTime:   "60",
+				Description:   "Mutating diseases are spreading around the world, can your team save humanity?",
+				Links:         links([]gobgg.Link{{ID: 442, Name: "Rob Daviau"}, {ID: 378, Name: "Matt Leacock"}}, []gobgg.Link{{ID: 2145, Name: "Medical"}}, []gobgg.Link{{ID: 3430, Name: "Series: Pandemic"}}),
+				UsersRated:    54000,
+				AverageRate:   8.52,
+				BayesAverage:  8.38,
+				UsersOwned:    85000,
+				UsersWishing:  12000,
+				NumWeight:     1800,
+				AverageWeight: 2.83,
+				Ranks: gobgg.Ranks{
+					rank(gobgg.RankKindSubtype, 1, "boardgame", "Board Game Rank", 1, 8.38),
+					rank(gobgg.RankKindFamily, 5497, "strategygames", "Strategy Game Rank", 1, 8.40),
+					rank(gobgg.RankKindFamily, 5496, "thematic", "Thematic Rank", 2, 8.43),
+				},
+			},
+			BreakDown: gobgg.RankBreakDown{400, 200, 300, 500, 900, 2000, 5500, 12300, 16200, 15700},
+		},
+		{
+			ThingResult: gobgg.ThingResult{
+				ID:            13,
+				Name:          "CATAN",
+				Type:          gobgg.BoardGameType,
+				YearPublished: 1995,
+				Thumbnail:     "https://cf.geekdo-images.com/catan__thumb/img/catan.jpg",
+				Image:         "https://cf.geekdo-images.com/catan__original/img/catan.jpg",
+				MinPlayers:    3,
+				MaxPlayers:    4,
+				SuggestedPlayerCount: []gobgg.SuggestedPlayerCount{
+					{NumPlayers: "3", Best: 600, Recommended: 1400, NotRecommended: 200},
+					{NumPlayers: "4", Best: 1900, Recommended: 400, NotRecommended: 50},
+				},
+				MinAge:        "10",
+				PlayTime:      "120",
+				MinPlayTime:   "60",
+				MaxPlayTime:   "120",
+				Description:   "Collect and trade resources to build up the island of Catan.",
+				Links:         links([]gobgg.Link{{ID: 11, Name: "Klaus Teuber"}}, []gobgg.Link{{ID: 1021, Name: "Economic"}}, []gobgg.Link{{ID: 3, Name: "Series: Catan"}}),
+				UsersRated:    120000,
+				AverageRate:   7.1,
+				BayesAverage:  6.93,
+				UsersOwned:    200000,
+				UsersWishing:  6000,
+				NumWeight:     8000,
+				AverageWeight: 2.29,
+				Ranks: gobgg.Ranks{
+					rank(gobgg.RankKindSubtype, 1, "boardgame", "Board Game Rank", 600, 6.93),
+					rank(gobgg.RankKindFamily, 5499, "familygames", "Family Game Rank", 120, 6.90),
+				},
+			},
+			BreakDown: gobgg.RankBreakDown{1500, 1800, 3000, 5200, 11000, 21000, 31000, 27000, 11000, 7500},
+		},
+		{
+			ThingResult: gobgg.ThingResult{
+				ID:            23383,
+				Name:          "Hokm",
+				Type:          gobgg.BoardGameType,
+				MinPlayers:    4,
+				MaxPlayers:    4,
+				MinAge:        "8",
+				PlayTime:      "30",
+				MinPlayTime:   "30",
+				MaxPlayTime:   "30",
+				Description:   "A trick taking card game from Iran.",
+				Links:         links(nil, []gobgg.Link{{ID: 1002, Name: "Card Game"}}, nil),
+				UsersRated:    40,
+				AverageRate:   7.2,
+				BayesAverage:  0,
+				UsersOwned:    60,
+				NumWeight:     5,
+				AverageWeight: 1.8,
+				Ranks: gobgg.Ranks{
+					rank(gobgg.RankKindSubtype, 1, "boardgame", "Board Game Rank", 0, 0),
+				},
+			},
+			BreakDown: gobgg.RankBreakDown{0, 0, 1, 2, 3, 5, 10, 10, 5, 4},
+		},
+	}
+}
+
+func collectionItem(g *Game, collID int64, modified time.Time, status ...string) gobgg.CollectionItem {
+	return gobgg.CollectionItem{
+		ID:               g.ID,
+		CollID:           collID,
+		Name:             g.Name,
+		Type:             g.Type,
+		YearPublished:    g.YearPublished,
+		Thumbnail:        g.Thumbnail,
+		Image:            g.Image,
+		LastModified:     modified,
+		MinPlayers:       g.MinPlayers,
+		MaxPlayers:       g.MaxPlayers,
+		NumOwned:         g.UsersOwned,
+		Average:          g.AverageRate,
+		BayesAverage:     g.BayesAverage,
+		Ranks:            g.Ranks,
+		CollectionStatus: status,
+	}
+}
+
+func play(id int64, g *Game, date string, minutes int, players ...gobgg.Player) gobgg.Play {
+	d, _ := time.Parse(dateFormat, date)
+
+	return gobgg.Play{
+		ID:         id,
+		Date:       d,
+		Quantity:   1,
+		Length:     time.Duration(minutes) * time.Minute,
+		NowInStats: true,
+		Location:   "Home",
+		Item: gobgg.Item{
+			Name:     g.Name,
+			Type:     g.Type,
+			ID:       g.ID,
+			Subtypes: []gobgg.ItemType{g.Type},
+		},
+		Players: players,
+	}
+}
+
+func loadFixtures(s *Server) {
+	s.games = defaultGames()
+	byID := make(map[int64]*Game, len(s.games))
+	for _, g := range s.games {
+		byID[g.ID] = g
+	}
+
+	modified := time.Date(2024, 3, 10, 18, 30, 0, 0, s.location)
+
+	arkNova := collectionItem(byID[342942], 100001, modified, "own")
+	arkNova.Rating = 9
+	arkNova.NumPlays = 2
+	arkNova.Comment = "Best zoo in town"
+	gloomhaven := collectionItem(byID[174430], 100002, modified.AddDate(0, 1, 0), "own", "prevowned")
+	gloomhaven.Rating = 8.5
+	gloomhaven.NumPlays = 1
+	pandemic := collectionItem(byID[161936], 100003, modified.AddDate(0, 2, 0), "wishlist", "musthave")
+	pandemic.WishListComment = "Season 1 first"
+	catan := collectionItem(byID[13], 100004, modified.AddDate(0, 3, 0), "own", "trade")
+	catan.Rating = 6
+	catan.NumPlays = 1
+
+	me := gobgg.Player{UserName: DemoUser, UserID: 1000001, Name: "Go BGG"}
+	friend := gobgg.Player{Name: "Friend"}
+	win := func(p gobgg.Player, score float64) gobgg.Player {
+		p.Score, p.Win = score, true
+		return p
+	}
+	lose := func(p gobgg.Player, score float64) gobgg.Player {
+		p.Score = score
+		return p
+	}
+
+	s.users = map[string]*User{
+		DemoUser: {
+			User: gobgg.User{
+				UserID:     1000001,
+				UserName:   DemoUser,
+				FirstName:  "Go",
+				LastName:   "BGG",
+				Year:       2015,
+				AvatarLink: "https://cf.geekdo-static.com/avatars/avatar_id1000001.jpg",
+			},
+			Password:   DemoPassword,
+			Collection: []gobgg.CollectionItem{arkNova, gloomhaven, pandemic, catan},
+			Plays: []gobgg.Play{
+				play(80000001, byID[342942], "2024-01-06", 150, win(me, 112), lose(friend, 97)),
+				play(80000002, byID[174430], "2024-01-13", 120, win(me, 1), win(friend, 1)),
+				play(80000003, byID[13], "2024-02-03", 90, lose(me, 8), win(friend, 10)),
+				play(80000004, byID[342942], "2024-02-17", 140, lose(me, 88), win(friend, 101)),
+			},
+		},
+	}
+
+	s.persons = map[int64]gobgg.PersonImage{
+		11:    {ID: 11, Thumbnail: "https://cf.geekdo-images.com/teuber__thumb/img/teuber.jpg", Image: "https://cf.geekdo-images.com/teuber__original/img/teuber.jpg"},
+		378:   {ID: 378, Thumbnail: "https://cf.geekdo-images.com/leacock__thumb/img/leacock.jpg", Image: "https://cf.geekdo-images.com/leacock__original/img/leacock.jpg"},
+		69802: {ID: 69802, Thumbnail: "https://cf.geekdo-images.com/childres__thumb/img/childres.jpg", Image: "https://cf.geekdo-images.com/childres__original/img/childres.jpg"},
+	}
+
+	s.geekLists = map[int64][]gobgg.ListItem{
+		DemoGeekList: {
+			{ID: 342942, Name: "Ark Nova", Description: "The zoo builder"},
+			{ID: 174430, Name: "Gloomhaven", Description: "The dungeon crawler"},
+			{ID: 161936, Name: "Pandemic Legacy: Season 1", Description: "The legacy game"},
+		},
+	}
+}
@@ -0,0 +1,232 @@
+package bggtest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+const (
+	hotnessCount  = 50
+	geekListPage  = 25
+	trendsCount   = 20
+	trendsDateFmt = "2006-01-02"
+)
+
+type hotnessItem struct {
+	ObjectType    string `json:"objecttype"`
+	ObjectID      string `json:"objectid"`
+	Delta         int    `json:"delta"`
+	Href          string `json:"href"`
+	Name          string `json:"name"`
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	ImageURL      string `json:"imageurl"`
+	YearPublished string `json:"yearpublished"`
+	Rank          string `json:"rank"`
+	Description   string `json:"description"`
+}
+
+// hotness lists the things in the catalog order, the geek site selects the type
+func (s *Server) hotness(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if typ := q.Get("objecttype"); typ != "" && typ != string(gobgg.HotnessThing) {
+		writeJSON(w, http.StatusOK, map[string]any{"items": []hotnessItem{}})
+		return
+	}
+
+	site := q.Get("geeksite")
+	if site == "" {
+		site = string(gobgg.GeekSiteBoardGame)
+	}
+	count, err := strconv.Atoi(q.Get("showcount"))
+	if err != nil || count < 1 || count > hotnessCount {
+		count = hotnessCount
+	}
+
+	s.lock.Lock()
+	items := make([]hotnessItem, 0, count)
+	for _, g := range s.games {
+		if len(items) >= count {
+			break
+		}
+		if !strings.HasPrefix(string(g.Type), site) {
+			continue
+		}
+		items = append(items, hotnessItem{
+			ObjectType:    "thing",
+			ObjectID:      fmt.Sprint(g.ID),
+			Href:          href(g),
+			Name:          g.Name,
+			ID:            fmt.Sprint(g.ID),
+			Type:          "things",
+			ImageURL:      g.Thumbnail,
+			YearPublished: fmt.Sprint(g.YearPublished),
+			Rank:          fmt.Sprint(len(items) + 1),
+			Description:   g.Description,
+		})
+	}
+	s.lock.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+type geekItem struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Href string `json:"href"`
+}
+
+type geekListItem struct {
+	Type   string   `json:"type"`
+	ID     string   `json:"id"`
+	ListID string   `json:"listid"`
+	Item   geekItem `json:"item"`
+	Body   string   `json:"body"`
+}
+
+func (s *Server) geekList(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	id, _ := strconv.ParseInt(q.Get("listid"), 10, 64)
+	page, _ := strconv.Atoi(q.Get("page"))
+	page = max(page, 1)
+
+	s.lock.Lock()
+	list, ok := s.geekLists[id]
+	s.lock.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "List not found"})
+		return
+	}
+
+	start := min((page-1)*geekListPage, len(list))
+	end := min(page*geekListPage, len(list))
+	data := make([]geekListItem, 0, end-start)
+	for i, item := range list[start:end] {
+		data = append(data, geekListItem{
+			Type:   "listitem",
+			ID:     fmt.Sprint(id*1000 + int64(start+i+1)),
+			ListID: fmt.Sprint(id),
+			Item: geekItem{
+				Type: "things",
+				ID:   fmt.Sprint(item.ID),
+				Name: item.Name,
+				Href: fmt.Sprintf("/boardgame/%d/%s", item.ID, slug(item.Name)),
+			},
+			Body: item.Description,
+		})
+	}
+
+	var result struct {
+		Data       []geekListItem `json:"data"`
+		Pagination struct {
+			PageID  int `json:"pageid"`
+			PerPage int `json:"perPage"`
+			Total   int `json:"total"`
+		} `json:"pagination"`
+	}
+	result.Data = data
+	result.Pagination.PageID = page
+	result.Pagination.PerPage = geekListPage
+	result.Pagination.Total = len(list)
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+type trendDescriptor struct {
+	Name         string `json:"name"`
+	DisplayValue string `json:"displayValue"`
+}
+
+type trendItem struct {
+	ID   string `json:"id"`
+	Item struct {
+		Type        string            `json:"type"`
+		ID          string            `json:"id"`
+		Name        string            `json:"name"`
+		Href        string            `json:"href"`
+		Descriptors []trendDescriptor `json:"descriptors"`
+	} `json:"item"`
+	Rank        int `json:"rank"`
+	Delta       int `json:"delta"`
+	Appearances int `json:"appearances"`
+}
+
+type trendCount struct {
+	game  *Game
+	count int
+}
+
+// trends ranks the games by the number of owners (best sellers) or the plays in the interval
+func (s *Server) trends(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	interval := gobgg.TrendInterval(q.Get("interval"))
+	start, err := time.Parse(trendsDateFmt, q.Get("startDate"))
+	if err != nil || (interval != gobgg.TrendIntervalWeek && interval != gobgg.TrendIntervalMonth) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid interval"})
+		return
+	}
+	end := start.AddDate(0, 0, 7)
+	if interval == gobgg.TrendIntervalMonth {
+		end = start.AddDate(0, 1, 0)
+	}
+
+	s.lock.Lock()
+	counts := make(map[int64]int)
+	for _, u := range s.users {
+		if r.URL.Path == PathTrendsBestSeller {
+			for _, item := range u.Collection {
+				if slices.Contains(item.CollectionStatus, string(gobgg.CollectionTypeOwn)) {
+					counts[item.ID]++
+				}
+			}
+			continue
+		}
+		for _, p := range u.Plays {
+			if !p.Date.Before(start) && p.Date.Before(end) {
+				counts[p.Item.ID]++
+			}
+		}
+	}
+
+	var list []trendCount
+	for id, count := range counts {
+		if g := s.game(id); g != nil {
+			list = append(list, trendCount{game: g, count: count})
+		}
+	}
+	s.lock.Unlock()
+
+	slices.SortFunc(list, func(a, b trendCount) int {
+		if a.count != b.count {
+			return b.count - a.count
+		}
+		return int(a.game.ID - b.game.ID)
+	})
+
+	items := make([]trendItem, 0, min(len(list), trendsCount))
+	for i, tc := range list[:min(len(list), trendsCount)] {
+		item := trendItem{ID: fmt.Sprint(tc.game.ID), Rank: i + 1, Appearances: 1}
+		item.Item.Type = "things"
+		item.Item.ID = fmt.Sprint(tc.game.ID)
+		item.Item.Name = tc.game.Name
+		item.Item.Href = href(tc.game)
+		item.Item.Descriptors = []trendDescriptor{
+			{Name: "yearpublished", DisplayValue: fmt.Sprint(tc.game.YearPublished)},
+		}
+		items = append(items, item)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":    items,
+		"interval": interval,
+		"endDate":  end.Format(time.RFC3339),
+	})
+}
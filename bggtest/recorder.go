@@ -0,0 +1,339 @@
+package bggtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// EnvRecord is the environment variable that switches ModeFromEnv to the record mode
+const EnvRecord = "BGGTEST_RECORD"
+
+// redacted replaces the sensitive values in the recordings
+const redacted = "REDACTED"
+
+// ErrNoRecording is returned in the replay mode when there is no recording for the request
+var ErrNoRecording = errors.New("no recording for the request")
+
+// Mode is the recorder mode
+type Mode int
+
+const (
+	// ModeReplay only replays the recordings, the requests without a recording fail
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the real server and records the responses, the old
+	// recordings are overwritten
+	ModeRecord
+	// ModeAuto replays the existing recordings and records the missing ones
+	ModeAuto
+)
+
+// ModeFromEnv returns ModeRecord if the BGGTEST_RECORD environment variable is set to a
+// non-empty value, and ModeReplay otherwise, so the CI never calls the real server
+func ModeFromEnv() Mode {
+	if os.Getenv(EnvRecord) != "" {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+// sensitiveHeaders are redacted in the recordings, the cookie names are kept so the replayed
+// login still sets the cookies
+var sensitiveHeaders = []string{"Authorization", "X-Csrf-Token"}
+
+// sensitiveFields are the JSON fields that are redacted in the request bodies
+var sensitiveFields = []string{"password", "token"}
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized request
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized response
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Recorder is a http.RoundTripper that records the responses in a directory and replays them,
+// each interaction is a JSON file. Use it with gobgg.SetClient:
+//
+//	rec := bggtest.NewRecorder("testdata/recordings", bggtest.ModeFromEnv())
+//	bgg := gobgg.NewBGGClient(gobgg.SetClient(rec.Client()))
+type Recorder struct {
+	dir       string
+	mode      Mode
+	transport http.RoundTripper
+	sanitize  []func(*Interaction)
+
+	lock sync.Mutex
+	// seen is the number of the requests with the same key, the repeated requests (like the
+	// queued collection) are recorded in order
+	seen map[string]int
+}
+
+// RecorderOption is the recorder option
+type RecorderOption func(*Recorder)
+
+// RecordTransport sets the transport for the real requests, default is http.DefaultTransport
+func RecordTransport(rt http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = rt
+	}
+}
+
+// RecordSanitizer adds a function to remove the other sensitive data from the interactions
+// before they are written, the functions are called in order after the default sanitizer
+func RecordSanitizer(fn func(*Interaction)) RecorderOption {
+	return func(r *Recorder) {
+		r.sanitize = append(r.sanitize, fn)
+	}
+}
+
+// NewRecorder creates a recorder that keeps the recordings in the dir
+func NewRecorder(dir string, mode Mode, opts ...RecorderOption) *Recorder {
+	r := &Recorder{
+		dir:       dir,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		seen:      make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Client returns a http client that uses the recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip replays or records the request based on the mode
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read the request body failed: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: sanitizeHeader(req.Header),
+		Body:   sanitizeBody(body),
+	}
+	path := r.path(req.URL, recorded)
+
+	if r.mode != ModeRecord {
+		in, err := load(path)
+		switch {
+		case err == nil:
+			return in.Response.response(req), nil
+		case !errors.Is(err, os.ErrNotExist):
+			return nil, err
+		case r.mode == ModeReplay:
+			return nil, fmt.Errorf("%w: %s %s (%s)", ErrNoRecording, req.Method, recorded.URL, path)
+		}
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read the response body failed: %w", err)
+	}
+
+	in := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     sanitizeHeader(resp.Header),
+			Body:       string(respBody),
+		},
+	}
+	for _, fn := range r.sanitize {
+		fn(&in)
+	}
+	if err := save(path, &in); err != nil {
+		return nil, err
+	}
+
+	// The caller gets the real response, only the recording is sanitized
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// path returns the file name for the request, the name has the host and path for readability,
+// a hash of the method, URL and body, and the index for the repeated requests
+func (r *Recorder) path(u *url.URL, req RecordedRequest) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL + "\n" + req.Body))
+	key := hex.EncodeToString(sum[:])[:16]
+
+	r.lock.Lock()
+	idx := r.seen[key]
+	r.seen[key]++
+	r.lock.Unlock()
+
+	name := slug(u.Host + u.Path)
+	if len(name) > 60 {
+		name = name[:60]
+	}
+
+	return filepath.Join(r.dir, fmt.Sprintf("%s-%s-%d.json", name, key, idx))
+}
+
+func (rr *RecordedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rr.StatusCode, http.StatusText(rr.StatusCode)),
+		StatusCode:    rr.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rr.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(rr.Body)),
+		ContentLength: int64(len(rr.Body)),
+		Request:       req,
+	}
+}
+
+func sanitizeHeader(h http.Header) http.Header {
+	result := h.Clone()
+	for _, key := range sensitiveHeaders {
+		if len(result.Values(key)) > 0 {
+			result.Set(key, redacted)
+		}
+	}
+
+	if cookies := result.Values("Cookie"); len(cookies) > 0 {
+		var names []string
+		for _, line := range cookies {
+			parsed, _ := http.ParseCookie(line)
+			for _, c := range parsed {
+				names = append(names, c.Name+"="+redacted)
+			}
+		}
+		result.Set("Cookie", strings.Join(names, "; "))
+	}
+
+	if cookies := result.Values("Set-Cookie"); len(cookies) > 0 {
+		result.Del("Set-Cookie")
+		for _, line := range cookies {
+			c, err := http.ParseSetCookie(line)
+			if err != nil {
+				continue
+			}
+			c.Value = redacted
+			result.Add("Set-Cookie", c.String())
+		}
+	}
+
+	return result
+}
+
+// sanitizeBody redacts the sensitive fields in the JSON bodies, like the login password
+func sanitizeBody(body []byte) string {
+	var data any
+	if len(body) == 0 || json.Unmarshal(body, &data) != nil {
+		return string(body)
+	}
+
+	if !redactFields(data) {
+		return string(body)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(b)
+}
+
+func redactFields(data any) bool {
+	changed := false
+	switch t := data.(type) {
+	case map[string]any:
+		for key, val := range t {
+			for _, field := range sensitiveFields {
+				if strings.EqualFold(key, field) {
+					t[key] = redacted
+					changed = true
+				}
+			}
+			changed = redactFields(val) || changed
+		}
+	case []any:
+		for _, val := range t {
+			changed = redactFields(val) || changed
+		}
+	}
+
+	return changed
+}
+
+func load(path string) (*Interaction, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var in Interaction
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("invalid recording %q: %w", path, err)
+	}
+
+	return &in, nil
+}
+
+func save(path string, in *Interaction) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create the recordings dir failed: %w", err)
+	}
+
+	// The HTML escaping is disabled, so the XML bodies are readable in the diffs
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(in); err != nil {
+		return fmt.Errorf("marshal the recording failed: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write the recording failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package bggtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	srv := NewServer()
+
+	rec := NewRecorder(dir, ModeRecord, RecordTransport(srv.Client().Transport))
+	bgg := gobgg.NewBGGClient(gobgg.SetClient(rec.Client()), gobgg.SetAuthToken("my-token"))
+
+	require.NoError(t, bgg.Login(ctx, DemoUser, DemoPassword))
+	session := bgg.GetActiveCookies()[0].Value
+
+	things, err := bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	game, _ := srv.Game(13)
+	game.Name = "Settlers of Catan"
+	srv.AddGame(game)
+	renamed, err := bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	collection, err := bgg.GetCollection(ctx, DemoUser, gobgg.SetStats(true))
+	require.NoError(t, err)
+	plays, err := bgg.Plays(ctx, gobgg.SetUserName(DemoUser))
+	require.NoError(t, err)
+	srv.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), DemoPassword)
+		assert.NotContains(t, string(data), session)
+		assert.NotContains(t, string(data), "my-token")
+	}
+
+	// The server is closed, everything comes from the recordings
+	rec = NewRecorder(dir, ModeReplay)
+	bgg = gobgg.NewBGGClient(gobgg.SetClient(rec.Client()), gobgg.SetAuthToken("other-token"))
+
+	require.NoError(t, bgg.Login(ctx, DemoUser, "another password"))
+	assert.Equal(t, SessionCookie, bgg.GetActiveCookies()[0].Name)
+
+	replayed, err := bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	assert.Equal(t, things, replayed)
+	replayed, err = bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	assert.Equal(t, renamed, replayed)
+
+	replayedCollection, err := bgg.GetCollection(ctx, DemoUser, gobgg.SetStats(true))
+	require.NoError(t, err)
+	assert.Equal(t, collection, replayedCollection)
+
+	replayedPlays, err := bgg.Plays(ctx, gobgg.SetUserName(DemoUser))
+	require.NoError(t, err)
+	assert.Equal(t, plays, replayedPlays)
+
+	_, err = bgg.GetThings(ctx, gobgg.GetThingIDs(174430))
+	require.ErrorIs(t, err, ErrNoRecording)
+}
+
+func TestRecorderAuto(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	srv := NewServer()
+	defer srv.Close()
+
+	rec := NewRecorder(dir, ModeAuto, RecordTransport(srv.Client().Transport),
+		RecordSanitizer(func(in *Interaction) {
+			in.Response.Body = strings.ReplaceAll(in.Response.Body, "Klaus Teuber", "Designer")
+		}),
+		RecordSanitizer(func(in *Interaction) {
+			in.Response.Body = strings.ReplaceAll(in.Response.Body, "Designer", "The Designer")
+		}))
+	bgg := gobgg.NewBGGClient(gobgg.SetClient(rec.Client()))
+
+	// The first call is recorded and the caller gets the real response
+	things, err := bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	assert.Equal(t, "Klaus Teuber", things[0].GetLinkByName(gobgg.BoardGameDesigner)[0].Name)
+
+	// A new recorder replays the sanitized recording
+	bgg = gobgg.NewBGGClient(gobgg.SetClient(NewRecorder(dir, ModeAuto).Client()))
+	things, err = bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+	// Both sanitizers are applied
+	assert.Equal(t, "The Designer", things[0].GetLinkByName(gobgg.BoardGameDesigner)[0].Name)
+}
@@ -0,0 +1,118 @@
+package bggtest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replayClient replays the recordings in testdata/recordings, they are hand-written in the BGG
+// format, set BGGTEST_RECORD (and BGG_TOKEN) to record them from BGG. The schema drift is checked too
+func replayClient(t *testing.T) *gobgg.BGG {
+	t.Helper()
+
+	var drifts []gobgg.SchemaDrift
+	t.Cleanup(func() {
+		assert.Empty(t, drifts, "the client does not match the recorded payloads")
+	})
+
+	rec := NewRecorder("testdata/recordings", ModeFromEnv())
+	return gobgg.NewBGGClient(gobgg.SetClient(rec.Client()), gobgg.SetAuthToken(os.Getenv("BGG_TOKEN")),
+		gobgg.SetSchemaDriftHandler(func(d gobgg.SchemaDrift) {
+			drifts = append(drifts, d)
+		}))
+}
+
+func TestReplayThings(t *testing.T) {
+	things, err := replayClient(t).GetThings(context.Background(), gobgg.GetThingIDs(13, 174430, 926))
+	require.NoError(t, err)
+	require.Len(t, things, 3)
+
+	catan := things[0]
+	assert.Equal(t, int64(13), catan.ID)
+	assert.Equal(t, "CATAN", catan.Name)
+	assert.Contains(t, catan.AlternateNames, "Die Siedler von Catan")
+	assert.Equal(t, gobgg.BoardGameType, catan.Type)
+	assert.Equal(t, 1995, catan.YearPublished)
+	assert.Equal(t, 3, catan.MinPlayers)
+	assert.Equal(t, 4, catan.MaxPlayers)
+	assert.Contains(t, catan.Description, "cards)—wood")
+	assert.Len(t, catan.SuggestedPlayerCount, 5)
+	assert.Equal(t, "Klaus Teuber", catan.Designers()[0].Name)
+	assert.Len(t, catan.Publishers(), 2)
+	assert.Equal(t, 126587, catan.UsersRated)
+	assert.Equal(t, 2.2951, catan.AverageWeight)
+	assert.Equal(t, 583, catan.RankTotal)
+	rank, ok := catan.RankIn("strategygames")
+	require.True(t, ok)
+	assert.Equal(t, 418, rank.Value)
+
+	assert.Equal(t, "Gloomhaven", things[1].Name)
+	assert.Equal(t, 3, things[1].RankTotal)
+
+	expansion := things[2]
+	assert.Equal(t, gobgg.BoardGameExpansionType, expansion.Type)
+	rank, ok = expansion.Ranks.Subtype()
+	require.True(t, ok)
+	assert.False(t, rank.Ranked)
+}
+
+func TestReplayCollection(t *testing.T) {
+	// The first recorded response is the queued (202) one
+	items, err := replayClient(t).GetCollection(context.Background(), DemoUser, gobgg.SetStats(true))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	catan := items[0]
+	assert.Equal(t, int64(13), catan.ID)
+	assert.Equal(t, int64(38517391), catan.CollID)
+	assert.Equal(t, 6.0, catan.Rating)
+	assert.Equal(t, 12, catan.NumPlays)
+	assert.Equal(t, "The first copy, the box is worn", catan.Comment)
+	assert.ElementsMatch(t, []string{"own", "trade", "played"}, catan.CollectionStatus)
+	assert.Equal(t, 2023, catan.LastModified.Year())
+
+	gloom := items[1]
+	assert.Zero(t, gloom.Rating)
+	assert.Equal(t, "Waiting for the second edition", gloom.WishListComment)
+	assert.ElementsMatch(t, []string{"wishlist", "lovetohave"}, gloom.CollectionStatus)
+	assert.Equal(t, 8.5876, gloom.Average)
+
+	expansion := items[2]
+	assert.Equal(t, gobgg.BoardGameExpansionType, expansion.Type)
+	assert.Equal(t, 7.5, expansion.Rating)
+	rank, ok := expansion.Ranks.Subtype()
+	require.True(t, ok)
+	assert.False(t, rank.Ranked)
+}
+
+func TestReplayPlays(t *testing.T) {
+	plays, err := replayClient(t).Plays(context.Background(), gobgg.SetUserName(DemoUser))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), plays.Total)
+	assert.Equal(t, DemoUser, plays.UserName)
+	require.Len(t, plays.Items, 3)
+
+	play := plays.Items[0]
+	assert.Equal(t, int64(84518317), play.ID)
+	assert.Equal(t, "2024-02-17", play.Date.Format("2006-01-02"))
+	assert.Equal(t, "Home", play.Location)
+	assert.Equal(t, "CATAN", play.Item.Name)
+	require.Len(t, play.Players, 3)
+	assert.True(t, play.Players[0].Win)
+	assert.Equal(t, int64(1000001), play.Players[0].UserID)
+	assert.Equal(t, 7.5, play.Players[2].Score)
+	assert.True(t, play.Players[2].New)
+
+	assert.Equal(t, 2.0, plays.Items[1].Quantity)
+	assert.Empty(t, plays.Items[1].Players)
+	assert.Equal(t, []gobgg.ItemType{gobgg.BoardGameType, gobgg.BoardGameExpansionType}, plays.Items[1].Item.Subtypes)
+
+	assert.True(t, plays.Items[2].Incomplete)
+	assert.False(t, plays.Items[2].NowInStats)
+	assert.Empty(t, plays.Items[2].Players[0].ScoreText)
+}
@@ -0,0 +1,363 @@
+// Package bggtest is a fake BoardGameGeek server for the tests and the local development. It serves
+// the XML API, the site endpoints (login, logging plays, rating...) and the geekdo JSON APIs from an
+// in memory catalog, so the code that uses gobgg can be tested without the network:
+//
+//	srv := bggtest.NewServer()
+//	defer srv.Close()
+//
+//	bgg := gobgg.NewBGGClient(srv.ClientOptions()...)
+package bggtest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// The paths of the endpoints, they can be used in Fail and ErrorEnvelope
+const (
+	PathThing            = "/xmlapi2/thing"
+	PathCollection       = "/xmlapi2/collection"
+	PathPlays            = "/xmlapi2/plays"
+	PathSearch           = "/xmlapi2/search"
+	PathUser             = "/xmlapi2/user"
+	PathPerson           = "/xmlapi2/person"
+	PathLogin            = "/login/api/v1"
+	PathPostPlay         = "/geekplay.php"
+	PathCollections      = "/api/collections"
+	PathRate             = "/api/collectionitems/"
+	PathRankBreakDown    = "/api/collectionstatsgraph"
+	PathQuickSearch      = "/search/"
+	PathBrowse           = "/browse/boardgame/page/"
+	PathBoardGame        = "/boardgame/"
+	PathHotness          = "/api/hotness"
+	PathGeekList         = "/api/listitems"
+	PathTrendsBestSeller = "/api/trends/ownership"
+	PathTrendsMostPlays  = "/api/trends/plays"
+	PathTrendsPlaysDelta = "/api/trends/plays_delta"
+)
+
+// SessionCookie is the cookie that the login sets and the write endpoints require
+const SessionCookie = "SessionID"
+
+// Game is a thing in the catalog, the break down is the number of votes for each rating
+type Game struct {
+	gobgg.ThingResult
+	BreakDown gobgg.RankBreakDown
+}
+
+// User is a BGG user with the password for the login, the collection and the plays
+type User struct {
+	gobgg.User
+	Password   string
+	Collection []gobgg.CollectionItem
+	Plays      []gobgg.Play
+}
+
+type fault struct {
+	status int
+	body   string
+	times  int
+}
+
+// Server is the fake BGG server, it is safe for concurrent use
+type Server struct {
+	*httptest.Server
+
+	lock      sync.Mutex
+	games     []*Game
+	users     map[string]*User
+	persons   map[int64]gobgg.PersonImage
+	geekLists map[int64][]gobgg.ListItem
+	sessions  map[string]string
+	faults    map[string][]*fault
+	queue     int
+	queued    map[string]int
+	location  *time.Location
+	nextID    int64
+}
+
+// Option is the server option
+type Option func(*Server)
+
+// Empty starts the server without the default fixtures
+func Empty() Option {
+	return func(s *Server) {
+		s.games = nil
+		s.users = make(map[string]*User)
+		s.persons = make(map[int64]gobgg.PersonImage)
+		s.geekLists = make(map[int64][]gobgg.ListItem)
+	}
+}
+
+// QueueCollection makes the collection API answer with 202 (queued) n times for each collection
+// before the result is ready, like BGG does for the collections that are not in its cache
+func QueueCollection(n int) Option {
+	return func(s *Server) {
+		s.queue = n
+	}
+}
+
+// ServerLocation sets the server time zone, it is used for the collection modified dates. Default
+// is the same as the gobgg client default
+func ServerLocation(loc *time.Location) Option {
+	return func(s *Server) {
+		s.location = loc
+	}
+}
+
+func defaultLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+
+	return loc
+}
+
+// NewServer starts a new fake server with the default fixtures, the caller should close it
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		sessions: make(map[string]string),
+		faults:   make(map[string][]*fault),
+		queued:   make(map[string]int),
+		location: defaultLocation(),
+		nextID:   90000000,
+	}
+	loadFixtures(s)
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathThing, s.thing)
+	mux.HandleFunc(PathCollection, s.collection)
+	mux.HandleFunc(PathPlays, s.plays)
+	mux.HandleFunc(PathSearch, s.search)
+	mux.HandleFunc(PathUser, s.user)
+	mux.HandleFunc(PathPerson, s.person)
+	mux.HandleFunc(PathLogin, s.login)
+	mux.HandleFunc(PathPostPlay, s.postPlay)
+	mux.HandleFunc(PathCollections, s.collections)
+	mux.HandleFunc(PathRate, s.rate)
+	mux.HandleFunc(PathRankBreakDown, s.rankBreakDown)
+	mux.HandleFunc(PathQuickSearch, s.quickSearch)
+	mux.HandleFunc(PathBrowse, s.browse)
+	mux.HandleFunc(PathBoardGame, s.boardGame)
+	mux.HandleFunc(PathHotness, s.hotness)
+	mux.HandleFunc(PathGeekList, s.geekList)
+	mux.HandleFunc(PathTrendsBestSeller, s.trends)
+	mux.HandleFunc(PathTrendsMostPlays, s.trends)
+	mux.HandleFunc(PathTrendsPlaysDelta, s.trends)
+
+	s.Server = httptest.NewServer(s.withFaults(mux))
+
+	return s
+}
+
+// rewriteTransport sends all the requests to the fake server, the geekdo APIs are called with
+// their full URL and SetHost has no effect on them
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+
+	return t.base.RoundTrip(r)
+}
+
+// Client returns a http client that sends the requests for any host to the fake server
+func (s *Server) Client() *http.Client {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		panic(fmt.Sprintf("invalid server url %q: %s", s.URL, err))
+	}
+
+	return &http.Client{
+		Transport: &rewriteTransport{target: u, base: s.Server.Client().Transport},
+	}
+}
+
+// ClientOptions returns the gobgg options to use the fake server
+func (s *Server) ClientOptions() []gobgg.OptionSetter {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		panic(fmt.Sprintf("invalid server url %q: %s", s.URL, err))
+	}
+
+	return []gobgg.OptionSetter{
+		gobgg.SetHost(u.Host),
+		gobgg.SetSchema(u.Scheme),
+		gobgg.SetClient(s.Client()),
+		gobgg.SetServerLocation(s.location),
+	}
+}
+
+// AddGame adds (or replaces) the games in the catalog
+func (s *Server) AddGame(games ...Game) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := range games {
+		g := games[i]
+		idx := slices.IndexFunc(s.games, func(old *Game) bool { return old.ID == g.ID })
+		if idx >= 0 {
+			s.games[idx] = &g
+			continue
+		}
+		s.games = append(s.games, &g)
+	}
+}
+
+// Game returns a copy of the game
+func (s *Server) Game(id int64) (Game, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	g := s.game(id)
+	if g == nil {
+		return Game{}, false
+	}
+
+	return *g, true
+}
+
+func (s *Server) game(id int64) *Game {
+	for _, g := range s.games {
+		if g.ID == id {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// AddUser adds (or replaces) the users
+func (s *Server) AddUser(users ...User) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := range users {
+		u := users[i]
+		s.users[strings.ToLower(u.UserName)] = &u
+	}
+}
+
+// User returns a copy of the user, it has the plays and the ratings that are sent to the server
+func (s *Server) User(username string) (User, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	u, ok := s.users[strings.ToLower(username)]
+	if !ok {
+		return User{}, false
+	}
+
+	cp := *u
+	cp.Collection = slices.Clone(u.Collection)
+	cp.Plays = slices.Clone(u.Plays)
+
+	return cp, true
+}
+
+// AddPerson adds the persons (designers, artists...) images
+func (s *Server) AddPerson(persons ...gobgg.PersonImage) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for i := range persons {
+		s.persons[persons[i].ID] = persons[i]
+	}
+}
+
+// AddGeekList adds (or replaces) a geek list
+func (s *Server) AddGeekList(id int64, items ...gobgg.ListItem) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.geekLists[id] = items
+}
+
+// Fail makes the next requests to the path fail with the status, 429 responses have the
+// Retry-After header like BGG
+func (s *Server) Fail(path string, status int, times int) {
+	s.addFault(path, &fault{status: status, body: http.StatusText(status), times: times})
+}
+
+// ErrorEnvelope makes the next requests to the path return the BGG error envelope, with the
+// 200 status code like BGG
+func (s *Server) ErrorEnvelope(path, message string, times int) {
+	s.addFault(path, &fault{status: http.StatusOK, body: errorEnvelope(message), times: times})
+}
+
+func (s *Server) addFault(path string, f *fault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.faults[path] = append(s.faults[path], f)
+}
+
+func (s *Server) nextFault(path string) *fault {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for key, list := range s.faults {
+		if key != path && !(strings.HasSuffix(key, "/") && strings.HasPrefix(path, key)) {
+			continue
+		}
+		if len(list) == 0 {
+			continue
+		}
+
+		f := *list[0]
+		list[0].times--
+		if list[0].times <= 0 {
+			s.faults[key] = list[1:]
+		}
+		return &f
+	}
+
+	return nil
+}
+
+func (s *Server) withFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f := s.nextFault(r.URL.Path)
+		if f == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if f.status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(f.body))
+	})
+}
+
+// session returns the logged in username, or empty string
+func (s *Server) session(r *http.Request) string {
+	c, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return ""
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.sessions[c.Value]
+}
@@ -0,0 +1,267 @@
+package bggtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClient(t *testing.T, opts ...Option) (*Server, *gobgg.BGG) {
+	t.Helper()
+
+	srv := NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	return srv, gobgg.NewBGGClient(srv.ClientOptions()...)
+}
+
+func TestThings(t *testing.T) {
+	ctx := context.Background()
+	_, bgg := newClient(t)
+
+	things, err := bgg.GetThings(ctx, gobgg.GetThingIDs(342942, 23383, 999), gobgg.GetThingsFullURL(true))
+	require.NoError(t, err)
+	require.Len(t, things, 2)
+
+	ark := things[0]
+	assert.Equal(t, "Ark Nova", ark.Name)
+	assert.Equal(t, []string{"アークノヴァ"}, ark.AlternateNames)
+	assert.Equal(t, 2021, ark.YearPublished)
+	assert.Equal(t, 3, ark.RankTotal)
+	assert.Equal(t, 2, ark.Family["strategygames"].Rank)
+	assert.Equal(t, 8.54, ark.AverageRate)
+	assert.Equal(t, 3.76, ark.AverageWeight)
+	assert.Len(t, ark.SuggestedPlayerCount, 4)
+	assert.Equal(t, "Mathias Wigge", ark.GetLinkByName(gobgg.BoardGameDesigner)[0].Name)
+	assert.Contains(t, ark.BGGURL, "/boardgame/342942/ark-nova")
+
+	hokm := things[1]
+	assert.Equal(t, 0, hokm.RankTotal)
+	rank, ok := hokm.RankIn("boardgame")
+	require.True(t, ok)
+	assert.False(t, rank.Ranked)
+
+	_, err = bgg.GetThings(ctx)
+	require.Error(t, err)
+
+	bd, err := bgg.GetRankBreakDown(ctx, 13)
+	require.NoError(t, err)
+	assert.EqualValues(t, 120000, bd.Total())
+}
+
+func TestCollection(t *testing.T) {
+	ctx := context.Background()
+	srv, bgg := newClient(t, QueueCollection(1))
+
+	start := time.Now()
+	items, err := bgg.GetCollection(ctx, DemoUser, gobgg.SetCollectionTypes(gobgg.CollectionTypeOwn), gobgg.SetStats(true))
+	require.NoError(t, err)
+	assert.Greater(t, time.Since(start), time.Second, "the first request should be queued")
+	require.Len(t, items, 3)
+	assert.Equal(t, "Ark Nova", items[0].Name)
+	assert.Equal(t, 9.0, items[0].Rating)
+	assert.Equal(t, "Best zoo in town", items[0].Comment)
+	assert.Contains(t, items[0].CollectionStatus, "own")
+	assert.Contains(t, items[2].CollectionStatus, "trade")
+
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	srv.lock.Lock()
+	srv.queue = 0
+	srv.lock.Unlock()
+	items, err = bgg.GetCollection(ctx, DemoUser, gobgg.SetModifiedSince(since))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Pandemic Legacy: Season 1", items[0].Name)
+	assert.Contains(t, items[0].CollectionStatus, "musthave")
+	assert.Zero(t, items[0].Rating, "the rating is only available with the stats")
+
+	_, err = bgg.GetCollection(ctx, "nobody")
+	require.ErrorContains(t, err, "Invalid username specified")
+}
+
+func TestPlays(t *testing.T) {
+	ctx := context.Background()
+	_, bgg := newClient(t)
+
+	plays, err := bgg.Plays(ctx, gobgg.SetUserName(DemoUser))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, plays.Total)
+	require.Len(t, plays.Items, 4)
+	assert.Equal(t, "Ark Nova", plays.Items[0].Item.Name, "the newest play comes first")
+	assert.Equal(t, "Friend", plays.Items[0].Players[1].Name)
+	assert.True(t, plays.Items[0].Players[1].Win)
+
+	plays, err = bgg.Plays(ctx, gobgg.SetGameID(342942))
+	require.NoError(t, err)
+	assert.Len(t, plays.Items, 2)
+
+	plays, err = bgg.Plays(ctx, gobgg.SetFamilyID(37417))
+	require.NoError(t, err)
+	require.Len(t, plays.Items, 1)
+	assert.Equal(t, "Gloomhaven", plays.Items[0].Item.Name)
+}
+
+func TestSearchAndUsers(t *testing.T) {
+	ctx := context.Background()
+	_, bgg := newClient(t)
+
+	result, err := bgg.Search(ctx, "an")
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	result, err = bgg.Search(ctx, "catan", gobgg.SearchExact())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.EqualValues(t, 13, result[0].ID)
+
+	quick, err := bgg.QuickSearch(ctx, "glo")
+	require.NoError(t, err)
+	require.Len(t, quick, 1)
+	assert.Equal(t, "Gloomhaven", quick[0].Name)
+
+	user, err := bgg.GetUser(ctx, DemoUser)
+	require.NoError(t, err)
+	assert.Equal(t, "Go", user.FirstName)
+	assert.Equal(t, 2015, user.Year)
+
+	person, err := bgg.PersonImage(ctx, 11)
+	require.NoError(t, err)
+	assert.Contains(t, person.Image, "teuber")
+}
+
+func TestLoginAndWrite(t *testing.T) {
+	ctx := context.Background()
+	srv, bgg := newClient(t)
+
+	require.Error(t, bgg.Login(ctx, DemoUser, "wrong"))
+	require.Error(t, bgg.SetRank(ctx, 13, 8))
+
+	// Without the session cookie the server rejects the writes
+	anon := gobgg.NewBGGClient(append(srv.ClientOptions(),
+		gobgg.SetCookies(DemoUser, []*http.Cookie{{Name: SessionCookie, Value: "invalid"}}))...)
+	require.Error(t, anon.SetRank(ctx, 13, 8))
+
+	require.NoError(t, bgg.Login(ctx, DemoUser, DemoPassword))
+	require.NoError(t, bgg.SetRank(ctx, 13, 7.5))
+	// Only the items in the collection can be rated
+	require.Error(t, bgg.SetRank(ctx, 23383, 8))
+
+	count, err := bgg.PostPlay(ctx, &gobgg.Play{
+		Date:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Length: 45 * time.Minute,
+		Item:   gobgg.Item{ID: 13, Type: gobgg.BoardGameType},
+		Players: []gobgg.Player{
+			{Name: "Go BGG", UserName: DemoUser, Score: 10, Win: true},
+			{Name: "Friend", Score: 7},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	user, ok := srv.User(DemoUser)
+	require.True(t, ok)
+	require.Len(t, user.Plays, 5)
+	assert.Equal(t, 45*time.Minute, user.Plays[4].Length)
+	assert.Equal(t, "CATAN", user.Plays[4].Item.Name)
+	require.Len(t, user.Collection, 4)
+	assert.Equal(t, 7.5, user.Collection[3].Rating)
+}
+
+func TestRankingsAndGeekdo(t *testing.T) {
+	ctx := context.Background()
+	_, bgg := newClient(t)
+
+	rankings, err := bgg.Rankings(ctx)
+	require.NoError(t, err)
+	require.Len(t, rankings, 4)
+	assert.Equal(t, "Pandemic Legacy: Season 1", rankings[0].Name)
+	assert.Equal(t, 600, rankings[3].Rank)
+
+	rankings, err = bgg.Rankings(ctx, gobgg.RankingsSubdomain(gobgg.SubdomainThematic))
+	require.NoError(t, err)
+	require.Len(t, rankings, 2)
+	assert.EqualValues(t, 174430, rankings[0].ID)
+
+	rankings, err = bgg.Rankings(ctx, gobgg.RankingsSortBy(gobgg.RankingsSortNumVoters))
+	require.NoError(t, err)
+	assert.Equal(t, "CATAN", rankings[0].Name)
+
+	hot, err := bgg.HotItems(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, hot, 2)
+	assert.Equal(t, "Ark Nova", hot[0].Name)
+
+	list, err := bgg.GeekList(ctx, DemoGeekList)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, "The legacy game", list[2].Description)
+
+	plays, err := bgg.MostPlays(ctx, gobgg.TrendIntervalMonth, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, plays, 2)
+	assert.Equal(t, "Gloomhaven", plays[0].Name, "the lower id comes first on a tie")
+
+	sellers, err := bgg.BestSellers(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Len(t, sellers, 3)
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	srv, bgg := newClient(t, Empty())
+
+	_, err := bgg.GetThings(ctx, gobgg.GetThingIDs(13))
+	require.NoError(t, err)
+
+	srv.AddGame(Game{ThingResult: gobgg.ThingResult{ID: 1, Name: "One", Type: gobgg.BoardGameType}})
+	srv.ErrorEnvelope(PathThing, "Rate limit exceeded", 1)
+	_, err = bgg.GetThings(ctx, gobgg.GetThingIDs(1))
+	require.ErrorContains(t, err, "Rate limit exceeded")
+
+	things, err := bgg.GetThings(ctx, gobgg.GetThingIDs(1))
+	require.NoError(t, err)
+	require.Len(t, things, 1)
+
+	srv.Fail(PathQuickSearch, http.StatusTooManyRequests, 2)
+	_, err = bgg.QuickSearch(ctx, "one")
+	require.ErrorContains(t, err, "429")
+	_, err = bgg.QuickSearch(ctx, "one")
+	require.Error(t, err)
+	result, err := bgg.QuickSearch(ctx, "one")
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	resp, err := srv.Client().Get("https://boardgamegeek.com" + PathSearch + "?query=x")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestSchema(t *testing.T) {
+	ctx := context.Background()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	var drifts []gobgg.SchemaDrift
+	bgg := gobgg.NewBGGClient(append(srv.ClientOptions(), gobgg.SetSchemaDriftHandler(func(d gobgg.SchemaDrift) {
+		drifts = append(drifts, d)
+	}))...)
+
+	_, err := bgg.GetThings(ctx, gobgg.GetThingIDs(342942, 23383, 13))
+	require.NoError(t, err)
+	_, err = bgg.GetCollection(ctx, DemoUser, gobgg.SetStats(true))
+	require.NoError(t, err)
+	_, err = bgg.Plays(ctx, gobgg.SetUserName(DemoUser))
+	require.NoError(t, err)
+	_, err = bgg.Search(ctx, "an")
+	require.NoError(t, err)
+	_, err = bgg.GetUser(ctx, DemoUser)
+	require.NoError(t, err)
+
+	assert.Empty(t, drifts, "the fake server should match the client")
+}
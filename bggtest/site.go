@@ -0,0 +1,487 @@
+package bggtest
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+const rowsPerPage = 100
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(name string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func href(g *Game) string {
+	return fmt.Sprintf("/%s/%d/%s", g.Type, g.ID, slug(g.Name))
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+type loginRequest struct {
+	Credentials struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"credentials"`
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request"})
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	u, ok := s.users[strings.ToLower(req.Credentials.Username)]
+	if !ok || u.Password != req.Credentials.Password {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Invalid username or password"})
+		return
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	token := hex.EncodeToString(b)
+	s.sessions[token] = strings.ToLower(u.UserName)
+
+	http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: token, Path: "/", HttpOnly: true})
+	http.SetCookie(w, &http.Cookie{Name: "bggusername", Value: u.UserName, Path: "/"})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// playRequest is the JSON that the site sends to log a play
+type playRequest struct {
+	Players []struct {
+		Name     string `json:"name"`
+		Username string `json:"username"`
+		UserID   int64  `json:"userid"`
+		Color    string `json:"color"`
+		Score    string `json:"score"`
+		Win      bool   `json:"win"`
+		New      bool   `json:"new"`
+	} `json:"players"`
+	Quantity   int    `json:"quantity"`
+	Location   string `json:"location"`
+	Length     int    `json:"length"`
+	Incomplete bool   `json:"incomplete"`
+	Comments   string `json:"comments"`
+	ObjectType string `json:"objecttype"`
+	ObjectID   string `json:"objectid"`
+	PlayDate   string `json:"playdate"`
+	Action     string `json:"action"`
+}
+
+func (s *Server) postPlay(w http.ResponseWriter, r *http.Request) {
+	username := s.session(r)
+	if username == "" {
+		writeJSON(w, http.StatusOK, map[string]string{"error": "You must login to save plays"})
+		return
+	}
+
+	var req playRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Action != "save" {
+		writeJSON(w, http.StatusOK, map[string]string{"error": "Invalid request"})
+		return
+	}
+
+	id, _ := strconv.ParseInt(req.ObjectID, 10, 64)
+	date, err := time.Parse(dateFormat, req.PlayDate)
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]string{"error": "Invalid date"})
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	g := s.game(id)
+	if g == nil {
+		writeJSON(w, http.StatusOK, map[string]string{"error": "Invalid item"})
+		return
+	}
+
+	s.nextID++
+	play := gobgg.Play{
+		ID:         s.nextID,
+		Date:       date,
+		Quantity:   float64(max(req.Quantity, 1)),
+		Length:     time.Duration(req.Length) * time.Minute,
+		Incomplete: req.Incomplete,
+		NowInStats: !req.Incomplete,
+		Location:   req.Location,
+		Comment:    req.Comments,
+		Item: gobgg.Item{
+			Name:     g.Name,
+			Type:     g.Type,
+			ID:       g.ID,
+			Subtypes: []gobgg.ItemType{g.Type},
+		},
+	}
+	for _, p := range req.Players {
+		player := gobgg.Player{
+			UserName:  p.Username,
+			UserID:    p.UserID,
+			Name:      p.Name,
+			Color:     p.Color,
+			ScoreText: p.Score,
+			NoScore:   strings.TrimSpace(p.Score) == "",
+			New:       p.New,
+			Win:       p.Win,
+		}
+		player.Score, _ = strconv.ParseFloat(p.Score, 64)
+		play.Players = append(play.Players, player)
+	}
+
+	u := s.users[username]
+	u.Plays = append(u.Plays, play)
+
+	count := 0
+	for i := range u.Plays {
+		if u.Plays[i].Item.ID == id {
+			count++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"playid": fmt.Sprint(play.ID), "numplays": count})
+}
+
+// siteCollectionItem is the collection item of the site API, the ids are strings like the site
+type siteCollectionItem struct {
+	CollID     string   `json:"collid"`
+	ObjectID   string   `json:"objectid"`
+	ObjectType string   `json:"objecttype"`
+	Rating     *float64 `json:"rating"`
+}
+
+func siteItem(item *gobgg.CollectionItem) siteCollectionItem {
+	result := siteCollectionItem{
+		CollID:     fmt.Sprint(item.CollID),
+		ObjectID:   fmt.Sprint(item.ID),
+		ObjectType: "thing",
+	}
+	if item.Rating > 0 {
+		result.Rating = &item.Rating
+	}
+
+	return result
+}
+
+// collections is the site API for the collection items of the logged in user
+func (s *Server) collections(w http.ResponseWriter, r *http.Request) {
+	username := s.session(r)
+	if username == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "You must login"})
+		return
+	}
+
+	id, _ := strconv.ParseInt(r.URL.Query().Get("objectid"), 10, 64)
+	userID, _ := strconv.ParseInt(r.URL.Query().Get("userid"), 10, 64)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items := []siteCollectionItem{}
+	u := s.users[username]
+	if u.UserID == userID {
+		for i := range u.Collection {
+			if u.Collection[i].ID == id {
+				items = append(items, siteItem(&u.Collection[i]))
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+// rate updates a collection item of the logged in user, the site sends the whole item
+func (s *Server) rate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	username := s.session(r)
+	if username == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "You must login"})
+		return
+	}
+
+	var req struct {
+		Item map[string]any `json:"item"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+		return
+	}
+
+	collID, _ := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, PathRate), 10, 64)
+	rating, _ := req.Item["rating"].(float64)
+	if rating < 0 || rating > 10 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid rating"})
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	u := s.users[username]
+	idx := slices.IndexFunc(u.Collection, func(item gobgg.CollectionItem) bool { return item.CollID == collID })
+	if idx < 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Invalid item"})
+		return
+	}
+	u.Collection[idx].Rating = rating
+	u.Collection[idx].LastModified = time.Now()
+
+	writeJSON(w, http.StatusOK, map[string]any{"item": siteItem(&u.Collection[idx])})
+}
+
+type breakDownCell struct {
+	V int64 `json:"v"`
+}
+
+type breakDownRow struct {
+	C []breakDownCell `json:"c"`
+}
+
+func (s *Server) rankBreakDown(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(r.URL.Query().Get("objectid"), 10, 64)
+
+	s.lock.Lock()
+	var bd gobgg.RankBreakDown
+	if g := s.game(id); g != nil {
+		bd = g.BreakDown
+	}
+	s.lock.Unlock()
+
+	rows := make([]breakDownRow, 0, len(bd))
+	for i := range bd {
+		rows = append(rows, breakDownRow{C: []breakDownCell{{V: int64(i + 1)}, {V: bd[i]}}})
+	}
+
+	var result struct {
+		Data struct {
+			Rows []breakDownRow `json:"rows"`
+		} `json:"data"`
+	}
+	result.Data.Rows = rows
+	writeJSON(w, http.StatusOK, result)
+}
+
+type quickSearchItem struct {
+	ObjectType    string `json:"objecttype"`
+	Subtype       string `json:"subtype"`
+	ObjectID      string `json:"objectid"`
+	Name          string `json:"name"`
+	Href          string `json:"href"`
+	YearPublished int    `json:"yearpublished,omitempty"`
+	Images        struct {
+		Thumb string `json:"thumb"`
+	} `json:"images"`
+}
+
+func (s *Server) quickSearch(w http.ResponseWriter, r *http.Request) {
+	typ := strings.Trim(strings.TrimPrefix(r.URL.Path, PathQuickSearch), "/")
+	query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+	count, err := strconv.Atoi(r.URL.Query().Get("showcount"))
+	if err != nil || count < 1 {
+		count = 20
+	}
+
+	s.lock.Lock()
+	var matches []*Game
+	for _, g := range s.games {
+		if string(g.Type) == typ && query != "" && strings.Contains(strings.ToLower(g.Name), query) {
+			matches = append(matches, g)
+		}
+	}
+	s.lock.Unlock()
+
+	// The most popular items come first
+	slices.SortStableFunc(matches, func(a, b *Game) int { return b.UsersRated - a.UsersRated })
+
+	items := make([]quickSearchItem, 0, len(matches))
+	for _, g := range matches[:min(count, len(matches))] {
+		item := quickSearchItem{
+			ObjectType:    "thing",
+			Subtype:       string(g.Type),
+			ObjectID:      fmt.Sprint(g.ID),
+			Name:          g.Name,
+			Href:          href(g),
+			YearPublished: g.YearPublished,
+		}
+		item.Images.Thumb = g.Thumbnail
+		items = append(items, item)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+type browseRow struct {
+	Rank          int
+	Href          string
+	Name          string
+	Thumbnail     string
+	YearPublished int
+	GeekRating    string
+	AverageRating string
+	NumVoters     int
+
+	average float64
+}
+
+var browseTemplate = template.Must(template.New("browse").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Browse Board Games | BoardGameGeek</title></head>
+<body>
+<table class="collection_table" id="collectionitems">
+	<tr>
+		<th class="collection_rank">Board Game Rank</th>
+		<th class="collection_thumbnail"></th>
+		<th class="collection_objectname">Title</th>
+		<th class="collection_bggrating">Geek Rating</th>
+		<th class="collection_bggrating">Avg Rating</th>
+		<th class="collection_bggrating">Num Voters</th>
+	</tr>
+{{- range . }}
+	<tr id='row_' >
+		<td class="collection_rank">{{ if .Rank }}{{ .Rank }}{{ else }}N/A{{ end }}</td>
+		<td class="collection_thumbnail"><a href="{{ .Href }}"><img alt="{{ .Name }}" src="{{ .Thumbnail }}" /></a></td>
+		<td class="collection_objectname">
+			<a href="{{ .Href }}" class='primary' >{{ .Name }}</a>
+			{{ if .YearPublished }}<span class='smallerfont dull'>({{ .YearPublished }})</span>{{ end }}
+		</td>
+		<td class="collection_bggrating">{{ .GeekRating }}</td>
+		<td class="collection_bggrating">{{ .AverageRating }}</td>
+		<td class="collection_bggrating">{{ .NumVoters }}</td>
+	</tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+// browseRank returns the rank of the game in the list, the subdomain id is zero for the overall rank
+func browseRank(g *Game, subdomainID int64) int {
+	for _, r := range g.Ranks {
+		if !r.Ranked {
+			continue
+		}
+		if (subdomainID == 0 && r.Kind == gobgg.RankKindSubtype) || (subdomainID != 0 && r.ID == subdomainID) {
+			return r.Value
+		}
+	}
+
+	return 0
+}
+
+func (s *Server) browse(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, PathBrowse), "/"))
+	if err != nil || page < 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	q := r.URL.Query()
+	var subdomainID int64
+	if q.Get("rankobjecttype") == "subdomain" {
+		subdomainID, _ = strconv.ParseInt(q.Get("rankobjectid"), 10, 64)
+	}
+	sortBy := q.Get("sort")
+
+	s.lock.Lock()
+	var rows []browseRow
+	for _, g := range s.games {
+		if g.Type != gobgg.BoardGameType {
+			continue
+		}
+		rank := browseRank(g, subdomainID)
+		if (sortBy == "" || sortBy == string(gobgg.RankingsSortRank) || subdomainID != 0) && rank == 0 {
+			continue
+		}
+		rows = append(rows, browseRow{
+			Rank:          rank,
+			Href:          href(g),
+			Name:          g.Name,
+			Thumbnail:     g.Thumbnail,
+			YearPublished: g.YearPublished,
+			GeekRating:    strconv.FormatFloat(g.BayesAverage, 'f', 3, 64),
+			AverageRating: strconv.FormatFloat(g.AverageRate, 'f', 2, 64),
+			NumVoters:     g.UsersRated,
+			average:       g.AverageRate,
+		})
+	}
+	s.lock.Unlock()
+
+	slices.SortStableFunc(rows, func(a, b browseRow) int {
+		switch sortBy {
+		case string(gobgg.RankingsSortAverage):
+			return cmp.Compare(b.average, a.average)
+		case string(gobgg.RankingsSortNumVoters):
+			return b.NumVoters - a.NumVoters
+		}
+		return a.Rank - b.Rank
+	})
+
+	start := min((page-1)*rowsPerPage, len(rows))
+	end := min(page*rowsPerPage, len(rows))
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = browseTemplate.Execute(w, rows[start:end])
+}
+
+// boardGame redirects /boardgame/{id} to the full URL with the name, like the site
+func (s *Server) boardGame(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 {
+		http.NotFound(w, r)
+		return
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.lock.Lock()
+	g := s.game(id)
+	s.lock.Unlock()
+
+	if g == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(parts) == 2 {
+		http.Redirect(w, r, href(g), http.StatusMovedPermanently)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = fmt.Fprintf(w, "<!DOCTYPE html><html><head><title>%s | Board Game | BoardGameGeek</title></head><body></body></html>",
+		template.HTMLEscapeString(g.Name))
+}
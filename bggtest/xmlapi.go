@@ -0,0 +1,774 @@
+package bggtest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+const (
+	termsOfUse   = "https://boardgamegeek.com/xmlapi/termsofuse"
+	dateFormat   = "2006-01-02"
+	dateTime     = "2006-01-02 15:04:05"
+	playsPerPage = 100
+)
+
+type value struct {
+	Value string `xml:"value,attr"`
+}
+
+func val(v any) *value {
+	return &value{Value: fmt.Sprint(v)}
+}
+
+func floatVal(f float64) *value {
+	return &value{Value: strconv.FormatFloat(f, 'f', -1, 64)}
+}
+
+type xmlName struct {
+	Type      string `xml:"type,attr"`
+	SortIndex int    `xml:"sortindex,attr"`
+	Value     string `xml:"value,attr"`
+}
+
+type xmlLink struct {
+	Type  string `xml:"type,attr"`
+	ID    int64  `xml:"id,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xmlResult struct {
+	Value    string `xml:"value,attr"`
+	NumVotes int    `xml:"numvotes,attr"`
+}
+
+type xmlPollResults struct {
+	NumPlayers string      `xml:"numplayers,attr"`
+	Result     []xmlResult `xml:"result"`
+}
+
+type xmlPoll struct {
+	Name       string           `xml:"name,attr"`
+	Title      string           `xml:"title,attr"`
+	TotalVotes int              `xml:"totalvotes,attr"`
+	Results    []xmlPollResults `xml:"results"`
+}
+
+type xmlRank struct {
+	Type         string `xml:"type,attr"`
+	ID           int64  `xml:"id,attr"`
+	Name         string `xml:"name,attr"`
+	FriendlyName string `xml:"friendlyname,attr"`
+	Value        string `xml:"value,attr"`
+	BayesAverage string `xml:"bayesaverage,attr"`
+}
+
+type xmlRatings struct {
+	UsersRated    *value    `xml:"usersrated"`
+	Average       *value    `xml:"average"`
+	BayesAverage  *value    `xml:"bayesaverage"`
+	Ranks         []xmlRank `xml:"ranks>rank"`
+	Owned         *value    `xml:"owned,omitempty"`
+	Trading       *value    `xml:"trading,omitempty"`
+	Wanting       *value    `xml:"wanting,omitempty"`
+	Wishing       *value    `xml:"wishing,omitempty"`
+	NumComments   *value    `xml:"numcomments,omitempty"`
+	NumWeights    *value    `xml:"numweights,omitempty"`
+	AverageWeight *value    `xml:"averageweight,omitempty"`
+}
+
+type xmlThing struct {
+	Type          string     `xml:"type,attr"`
+	ID            int64      `xml:"id,attr"`
+	Thumbnail     string     `xml:"thumbnail,omitempty"`
+	Image         string     `xml:"image,omitempty"`
+	Name          []xmlName  `xml:"name"`
+	Description   string     `xml:"description"`
+	YearPublished *value     `xml:"yearpublished"`
+	MinPlayers    *value     `xml:"minplayers"`
+	MaxPlayers    *value     `xml:"maxplayers"`
+	Poll          []xmlPoll  `xml:"poll"`
+	PlayingTime   *value     `xml:"playingtime"`
+	MinPlayTime   *value     `xml:"minplaytime"`
+	MaxPlayTime   *value     `xml:"maxplaytime"`
+	MinAge        *value     `xml:"minage"`
+	Link          []xmlLink  `xml:"link"`
+	Ratings       xmlRatings `xml:"statistics>ratings,omitempty"`
+}
+
+type xmlItems[T any] struct {
+	XMLName    xml.Name `xml:"items"`
+	Total      *int     `xml:"total,attr,omitempty"`
+	TotalItems *int     `xml:"totalitems,attr,omitempty"`
+	TermsOfUse string   `xml:"termsofuse,attr"`
+	PubDate    string   `xml:"pubdate,attr,omitempty"`
+	Item       []T      `xml:"item"`
+}
+
+type xmlError struct {
+	XMLName xml.Name `xml:"error"`
+	Message string   `xml:"message"`
+}
+
+func errorEnvelope(message string) string {
+	b, _ := xml.Marshal(xmlError{Message: message})
+	return xml.Header + string(b)
+}
+
+func writeXML(w http.ResponseWriter, status int, data any) {
+	b, err := xml.MarshalIndent(data, "", "\t")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_, _ = w.Write(b)
+}
+
+func writeError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	_, _ = w.Write([]byte(errorEnvelope(message)))
+}
+
+func xmlRanks(ranks gobgg.Ranks) []xmlRank {
+	result := make([]xmlRank, 0, len(ranks))
+	for _, r := range ranks {
+		value, bayes := "Not Ranked", "Not Ranked"
+		if r.Ranked {
+			value = fmt.Sprint(r.Value)
+			bayes = strconv.FormatFloat(r.BayesAverage, 'f', -1, 64)
+		}
+		result = append(result, xmlRank{
+			Type:         string(r.Kind),
+			ID:           r.ID,
+			Name:         r.Name,
+			FriendlyName: r.FriendlyName,
+			Value:        value,
+			BayesAverage: bayes,
+		})
+	}
+
+	return result
+}
+
+func thingXML(g *Game) xmlThing {
+	t := xmlThing{
+		Type:          string(g.Type),
+		ID:            g.ID,
+		Thumbnail:     g.Thumbnail,
+		Image:         g.Image,
+		Name:          []xmlName{{Type: "primary", SortIndex: 1, Value: g.Name}},
+		Description:   g.Description,
+		YearPublished: val(g.YearPublished),
+		MinPlayers:    val(g.MinPlayers),
+		MaxPlayers:    val(g.MaxPlayers),
+		PlayingTime:   val(g.PlayTime),
+		MinPlayTime:   val(g.MinPlayTime),
+		MaxPlayTime:   val(g.MaxPlayTime),
+		MinAge:        val(g.MinAge),
+		Ratings: xmlRatings{
+			UsersRated:    val(g.UsersRated),
+			Average:       floatVal(g.AverageRate),
+			BayesAverage:  floatVal(g.BayesAverage),
+			Ranks:         xmlRanks(g.Ranks),
+			Owned:         val(g.UsersOwned),
+			Trading:       val(g.UsersTrading),
+			Wanting:       val(g.UsersWanting),
+			Wishing:       val(g.UsersWishing),
+			NumComments:   val(g.NumComments),
+			NumWeights:    val(g.NumWeight),
+			AverageWeight: floatVal(g.AverageWeight),
+		},
+	}
+	for _, name := range g.AlternateNames {
+		t.Name = append(t.Name, xmlName{Type: "alternate", SortIndex: 1, Value: name})
+	}
+
+	if len(g.SuggestedPlayerCount) > 0 {
+		poll := xmlPoll{Name: "suggested_numplayers", Title: "User Suggested Number of Players"}
+		for _, sp := range g.SuggestedPlayerCount {
+			poll.TotalVotes += sp.Best + sp.Recommended + sp.NotRecommended
+			poll.Results = append(poll.Results, xmlPollResults{
+				NumPlayers: sp.NumPlayers,
+				Result: []xmlResult{
+					{Value: "Best", NumVotes: sp.Best},
+					{Value: "Recommended", NumVotes: sp.Recommended},
+					{Value: "Not Recommended", NumVotes: sp.NotRecommended},
+				},
+			})
+		}
+		t.Poll = append(t.Poll, poll)
+	}
+
+	categories := make([]string, 0, len(g.Links))
+	for cat := range g.Links {
+		categories = append(categories, cat)
+	}
+	slices.Sort(categories)
+	for _, cat := range categories {
+		for _, l := range g.Links[cat] {
+			t.Link = append(t.Link, xmlLink{Type: cat, ID: l.ID, Value: l.Name})
+		}
+	}
+
+	return t
+}
+
+func splitIDs(str string) []int64 {
+	var result []int64
+	for _, part := range strings.Split(str, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err == nil {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+func (s *Server) thing(w http.ResponseWriter, r *http.Request) {
+	ids := splitIDs(r.URL.Query().Get("id"))
+	if len(ids) == 0 {
+		writeError(w, "Invalid id")
+		return
+	}
+	if len(ids) > 20 {
+		writeError(w, "Cannot load more than 20 items")
+		return
+	}
+
+	s.lock.Lock()
+	items := make([]xmlThing, 0, len(ids))
+	for _, id := range ids {
+		if g := s.game(id); g != nil {
+			items = append(items, thingXML(g))
+		}
+	}
+	s.lock.Unlock()
+
+	writeXML(w, http.StatusOK, xmlItems[xmlThing]{TermsOfUse: termsOfUse, Item: items})
+}
+
+type xmlCollectionStatus struct {
+	Own              int    `xml:"own,attr"`
+	PrevOwned        int    `xml:"prevowned,attr"`
+	ForTrade         int    `xml:"fortrade,attr"`
+	Want             int    `xml:"want,attr"`
+	WantToPlay       int    `xml:"wanttoplay,attr"`
+	WantToBuy        int    `xml:"wanttobuy,attr"`
+	Wishlist         int    `xml:"wishlist,attr"`
+	WishlistPriority int    `xml:"wishlistpriority,attr,omitempty"`
+	Preordered       int    `xml:"preordered,attr"`
+	LastModified     string `xml:"lastmodified,attr"`
+}
+
+type xmlCollectionRating struct {
+	Value        string    `xml:"value,attr"`
+	UsersRated   *value    `xml:"usersrated"`
+	Average      *value    `xml:"average"`
+	BayesAverage *value    `xml:"bayesaverage"`
+	Ranks        []xmlRank `xml:"ranks>rank"`
+}
+
+type xmlCollectionStats struct {
+	MinPlayers  int                 `xml:"minplayers,attr"`
+	MaxPlayers  int                 `xml:"maxplayers,attr"`
+	MinPlayTime int                 `xml:"minplaytime,attr"`
+	MaxPlayTime int                 `xml:"maxplaytime,attr"`
+	PlayingTime int                 `xml:"playingtime,attr"`
+	NumOwned    int                 `xml:"numowned,attr"`
+	Rating      xmlCollectionRating `xml:"rating"`
+}
+
+type xmlCollectionName struct {
+	SortIndex int    `xml:"sortindex,attr"`
+	Value     string `xml:",chardata"`
+}
+
+type xmlCollectionItem struct {
+	ObjectType      string              `xml:"objecttype,attr"`
+	ObjectID        int64               `xml:"objectid,attr"`
+	Subtype         string              `xml:"subtype,attr"`
+	CollID          int64               `xml:"collid,attr"`
+	Description     string              `xml:",chardata"`
+	Name            xmlCollectionName   `xml:"name"`
+	OriginalName    string              `xml:"originalname,omitempty"`
+	YearPublished   string              `xml:"yearpublished,omitempty"`
+	Image           string              `xml:"image,omitempty"`
+	Thumbnail       string              `xml:"thumbnail,omitempty"`
+	Stats           *xmlCollectionStats `xml:"stats,omitempty"`
+	Status          xmlCollectionStatus `xml:"status"`
+	NumPlays        int                 `xml:"numplays"`
+	Comment         string              `xml:"comment,omitempty"`
+	WishlistComment string              `xml:"wishlistcomment,omitempty"`
+}
+
+var wishlistPriorities = map[string]int{
+	"musthave":        gobgg.WishListPriorityMustHave,
+	"lovetohave":      gobgg.WishListPriorityLoveToHave,
+	"liketohave":      gobgg.WishListPriorityLikeToHave,
+	"thinkingaboutit": gobgg.WishListPriorityThinkingAboutIt,
+	"donotbuy":        gobgg.WishListPriorityDoNotBuy,
+}
+
+func hasStatus(item *gobgg.CollectionItem, status gobgg.CollectionType) bool {
+	switch status {
+	case gobgg.CollectionTypeRated:
+		return item.Rating > 0
+	case gobgg.CollectionTypePlayed:
+		return item.NumPlays > 0
+	case gobgg.CollectionTypeComment:
+		return item.Comment != ""
+	}
+
+	return slices.Contains(item.CollectionStatus, string(status))
+}
+
+func (s *Server) collectionItemXML(item *gobgg.CollectionItem, stats bool) xmlCollectionItem {
+	flag := func(status gobgg.CollectionType) int {
+		if slices.Contains(item.CollectionStatus, string(status)) {
+			return 1
+		}
+		return 0
+	}
+
+	typ := item.Type
+	if typ == "" {
+		typ = gobgg.BoardGameType
+	}
+	x := xmlCollectionItem{
+		ObjectType:      "thing",
+		ObjectID:        item.ID,
+		Subtype:         string(typ),
+		CollID:          item.CollID,
+		Description:     item.Description,
+		Name:            xmlCollectionName{SortIndex: 1, Value: item.Name},
+		OriginalName:    item.OriginalName,
+		Image:           item.Image,
+		Thumbnail:       item.Thumbnail,
+		NumPlays:        item.NumPlays,
+		Comment:         item.Comment,
+		WishlistComment: item.WishListComment,
+		Status: xmlCollectionStatus{
+			Own:          flag(gobgg.CollectionTypeOwn),
+			PrevOwned:    flag(gobgg.CollectionTypePrevOwned),
+			ForTrade:     flag(gobgg.CollectionTypeTrade),
+			Want:         flag(gobgg.CollectionTypeWant),
+			WantToPlay:   flag(gobgg.CollectionTypeWantToPlay),
+			WantToBuy:    flag(gobgg.CollectionTypeWantToBuy),
+			Wishlist:     flag(gobgg.CollectionTypeWishList),
+			Preordered:   flag(gobgg.CollectionTypePreorder),
+			LastModified: item.LastModified.In(s.location).Format(dateTime),
+		},
+	}
+	if item.YearPublished > 0 {
+		x.YearPublished = fmt.Sprint(item.YearPublished)
+	}
+	for _, status := range item.CollectionStatus {
+		if p, ok := wishlistPriorities[status]; ok {
+			x.Status.WishlistPriority = p
+		}
+	}
+
+	if stats {
+		rating := "N/A"
+		if item.Rating > 0 {
+			rating = strconv.FormatFloat(item.Rating, 'f', -1, 64)
+		}
+		x.Stats = &xmlCollectionStats{
+			MinPlayers:  item.MinPlayers,
+			MaxPlayers:  item.MaxPlayers,
+			MinPlayTime: item.MinPlayTime,
+			MaxPlayTime: item.MaxPlayTime,
+			PlayingTime: item.PlayingTime,
+			NumOwned:    item.NumOwned,
+			Rating: xmlCollectionRating{
+				Value:        rating,
+				UsersRated:   val(0),
+				Average:      floatVal(item.Average),
+				BayesAverage: floatVal(item.BayesAverage),
+				Ranks:        xmlRanks(item.Ranks),
+			},
+		}
+	}
+
+	return x
+}
+
+// collectionFilters are the status filters that the client sends as name=1
+var collectionFilters = []gobgg.CollectionType{
+	gobgg.CollectionTypeOwn,
+	gobgg.CollectionTypeRated,
+	gobgg.CollectionTypePlayed,
+	gobgg.CollectionTypeComment,
+	gobgg.CollectionTypeTrade,
+	gobgg.CollectionTypeWant,
+	gobgg.CollectionTypeWishList,
+	gobgg.CollectionTypePreorder,
+	gobgg.CollectionTypeWantToPlay,
+	gobgg.CollectionTypeWantToBuy,
+	gobgg.CollectionTypePrevOwned,
+	gobgg.CollectionTypeHasParts,
+	gobgg.CollectionTypeWantParts,
+}
+
+func (s *Server) collection(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := strings.ToLower(q.Get("username"))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	u, ok := s.users[username]
+	if !ok {
+		writeError(w, "Invalid username specified")
+		return
+	}
+
+	if s.queued[r.URL.RawQuery] < s.queue {
+		s.queued[r.URL.RawQuery]++
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(xml.Header + `<message>Your request for this collection has been accepted and will be processed.  Please try again later for access.</message>`))
+		return
+	}
+	delete(s.queued, r.URL.RawQuery)
+
+	var filters []gobgg.CollectionType
+	for _, f := range collectionFilters {
+		if q.Get(string(f)) == "1" {
+			filters = append(filters, f)
+		}
+	}
+
+	var since time.Time
+	if ms := q.Get("modifiedsince"); ms != "" {
+		var err error
+		since, err = time.ParseInLocation(dateTime, ms, s.location)
+		if err != nil {
+			writeError(w, "Invalid modifiedsince")
+			return
+		}
+	}
+	ids := splitIDs(q.Get("id"))
+	stats := q.Get("stats") == "1"
+
+	var items []xmlCollectionItem
+	for i := range u.Collection {
+		item := &u.Collection[i]
+		if len(ids) > 0 && !slices.Contains(ids, item.ID) {
+			continue
+		}
+		if !since.IsZero() && item.LastModified.Before(since) {
+			continue
+		}
+		match := len(filters) == 0
+		for _, f := range filters {
+			match = match || hasStatus(item, f)
+		}
+		if !match {
+			continue
+		}
+		items = append(items, s.collectionItemXML(item, stats))
+	}
+
+	total := len(items)
+	writeXML(w, http.StatusOK, xmlItems[xmlCollectionItem]{
+		TotalItems: &total,
+		TermsOfUse: termsOfUse,
+		PubDate:    time.Now().UTC().Format(time.RFC1123Z),
+		Item:       items,
+	})
+}
+
+type xmlPlayer struct {
+	Username      string `xml:"username,attr"`
+	UserID        int64  `xml:"userid,attr"`
+	Name          string `xml:"name,attr"`
+	StartPosition string `xml:"startposition,attr"`
+	Color         string `xml:"color,attr"`
+	Score         string `xml:"score,attr"`
+	New           int    `xml:"new,attr"`
+	Rating        string `xml:"rating,attr"`
+	Win           int    `xml:"win,attr"`
+}
+
+type xmlPlay struct {
+	ID         int64  `xml:"id,attr"`
+	Date       string `xml:"date,attr"`
+	Quantity   string `xml:"quantity,attr"`
+	Length     int    `xml:"length,attr"`
+	Incomplete int    `xml:"incomplete,attr"`
+	NowInStats int    `xml:"nowinstats,attr"`
+	Location   string `xml:"location,attr"`
+	Item       struct {
+		Name       string  `xml:"name,attr"`
+		ObjectType string  `xml:"objecttype,attr"`
+		ObjectID   int64   `xml:"objectid,attr"`
+		Subtypes   []value `xml:"subtypes>subtype"`
+	} `xml:"item"`
+	Comments string      `xml:"comments,omitempty"`
+	Players  []xmlPlayer `xml:"players>player,omitempty"`
+}
+
+type xmlPlays struct {
+	XMLName    xml.Name  `xml:"plays"`
+	Username   string    `xml:"username,attr"`
+	UserID     int64     `xml:"userid,attr"`
+	Total      int       `xml:"total,attr"`
+	Page       int       `xml:"page,attr"`
+	TermsOfUse string    `xml:"termsofuse,attr"`
+	Play       []xmlPlay `xml:"play"`
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func playXML(p *gobgg.Play) xmlPlay {
+	x := xmlPlay{
+		ID:         p.ID,
+		Date:       p.Date.Format(dateFormat),
+		Quantity:   strconv.FormatFloat(p.Quantity, 'f', -1, 64),
+		Length:     int(p.Length.Minutes()),
+		Incomplete: boolInt(p.Incomplete),
+		NowInStats: boolInt(p.NowInStats),
+		Location:   p.Location,
+		Comments:   p.Comment,
+	}
+	x.Item.Name = p.Item.Name
+	x.Item.ObjectType = "thing"
+	x.Item.ObjectID = p.Item.ID
+	for _, st := range p.Item.Subtypes {
+		x.Item.Subtypes = append(x.Item.Subtypes, value{Value: string(st)})
+	}
+
+	for i := range p.Players {
+		pl := &p.Players[i]
+		rating := ""
+		if pl.Rating > 0 {
+			rating = strconv.FormatFloat(pl.Rating, 'f', -1, 64)
+		}
+		x.Players = append(x.Players, xmlPlayer{
+			Username:      pl.UserName,
+			UserID:        pl.UserID,
+			Name:          pl.Name,
+			StartPosition: pl.StartPositionString(),
+			Color:         pl.Color,
+			Score:         pl.ScoreString(),
+			New:           boolInt(pl.New),
+			Rating:        rating,
+			Win:           boolInt(pl.Win),
+		})
+	}
+
+	return x
+}
+
+// playOf checks if the play is for the id, the id is a family id for the family type. The caller
+// should hold the lock
+func (s *Server) playOf(p *gobgg.Play, id int64, typ string) bool {
+	if typ != string(gobgg.PlaysTypeFamily) {
+		return p.Item.ID == id
+	}
+
+	g := s.game(p.Item.ID)
+	if g == nil {
+		return false
+	}
+	for _, l := range g.Links[gobgg.BoardGameFamily] {
+		if l.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Server) plays(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	username := strings.ToLower(q.Get("username"))
+	gameID, _ := strconv.ParseInt(q.Get("id"), 10, 64)
+	if username == "" && gameID == 0 {
+		writeError(w, "Invalid object or user")
+		return
+	}
+
+	var minDate, maxDate time.Time
+	if v := q.Get("mindate"); v != "" {
+		minDate, _ = time.Parse(dateFormat, v)
+	}
+	if v := q.Get("maxdate"); v != "" {
+		maxDate, _ = time.Parse(dateFormat, v)
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	page = max(page, 1)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := xmlPlays{Page: page, TermsOfUse: termsOfUse}
+	var users []*User
+	if username != "" {
+		u, ok := s.users[username]
+		if !ok {
+			writeError(w, "Invalid object or user")
+			return
+		}
+		result.Username = u.UserName
+		result.UserID = u.UserID
+		users = append(users, u)
+	} else {
+		for _, u := range s.users {
+			users = append(users, u)
+		}
+	}
+
+	var plays []gobgg.Play
+	for _, u := range users {
+		for _, p := range u.Plays {
+			if gameID > 0 && !s.playOf(&p, gameID, q.Get("type")) {
+				continue
+			}
+			if (!minDate.IsZero() && p.Date.Before(minDate)) || (!maxDate.IsZero() && p.Date.After(maxDate)) {
+				continue
+			}
+			plays = append(plays, p)
+		}
+	}
+	// The newest plays come first, like BGG
+	slices.SortStableFunc(plays, func(a, b gobgg.Play) int {
+		if c := b.Date.Compare(a.Date); c != 0 {
+			return c
+		}
+		return int(b.ID - a.ID)
+	})
+
+	result.Total = len(plays)
+	for i := (page - 1) * playsPerPage; i < len(plays) && i < page*playsPerPage; i++ {
+		result.Play = append(result.Play, playXML(&plays[i]))
+	}
+
+	writeXML(w, http.StatusOK, result)
+}
+
+type xmlSearchItem struct {
+	Type          string  `xml:"type,attr"`
+	ID            int64   `xml:"id,attr"`
+	Name          xmlName `xml:"name"`
+	YearPublished *value  `xml:"yearpublished,omitempty"`
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := strings.ToLower(strings.TrimSpace(q.Get("query")))
+	if query == "" {
+		writeError(w, "Invalid query")
+		return
+	}
+	exact := q.Get("exact") == "1"
+	var types []string
+	if t := q.Get("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	s.lock.Lock()
+	var items []xmlSearchItem
+	for _, g := range s.games {
+		if len(types) > 0 && !slices.Contains(types, string(g.Type)) {
+			continue
+		}
+		name := strings.ToLower(g.Name)
+		if (exact && name != query) || (!exact && !strings.Contains(name, query)) {
+			continue
+		}
+		item := xmlSearchItem{
+			Type: string(g.Type),
+			ID:   g.ID,
+			Name: xmlName{Type: "primary", Value: g.Name},
+		}
+		if g.YearPublished != 0 {
+			item.YearPublished = val(g.YearPublished)
+		}
+		items = append(items, item)
+	}
+	s.lock.Unlock()
+
+	total := len(items)
+	writeXML(w, http.StatusOK, xmlItems[xmlSearchItem]{Total: &total, TermsOfUse: termsOfUse, Item: items})
+}
+
+type xmlUser struct {
+	XMLName        xml.Name `xml:"user"`
+	ID             int64    `xml:"id,attr"`
+	Name           string   `xml:"name,attr"`
+	TermsOfUse     string   `xml:"termsofuse,attr"`
+	FirstName      *value   `xml:"firstname"`
+	LastName       *value   `xml:"lastname"`
+	AvatarLink     *value   `xml:"avatarlink"`
+	YearRegistered *value   `xml:"yearregistered"`
+}
+
+func (s *Server) user(w http.ResponseWriter, r *http.Request) {
+	name := strings.ToLower(r.URL.Query().Get("name"))
+
+	s.lock.Lock()
+	u, ok := s.users[name]
+	s.lock.Unlock()
+
+	if !ok {
+		// BGG returns an empty user for the unknown names
+		writeXML(w, http.StatusOK, xmlUser{Name: r.URL.Query().Get("name"), TermsOfUse: termsOfUse})
+		return
+	}
+
+	avatar := u.AvatarLink
+	if avatar == "" {
+		avatar = "N/A"
+	}
+	writeXML(w, http.StatusOK, xmlUser{
+		ID:             u.UserID,
+		Name:           u.UserName,
+		TermsOfUse:     termsOfUse,
+		FirstName:      val(u.FirstName),
+		LastName:       val(u.LastName),
+		AvatarLink:     val(avatar),
+		YearRegistered: val(u.Year),
+	})
+}
+
+type xmlPerson struct {
+	Type      string `xml:"type,attr"`
+	ID        int64  `xml:"id,attr"`
+	Thumbnail string `xml:"thumbnail"`
+	Image     string `xml:"image"`
+}
+
+func (s *Server) person(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+		writeError(w, "Invalid id")
+		return
+	}
+
+	s.lock.Lock()
+	p, ok := s.persons[id]
+	s.lock.Unlock()
+
+	var items []xmlPerson
+	if ok {
+		items = append(items, xmlPerson{Type: "person", ID: id, Thumbnail: p.Thumbnail, Image: p.Image})
+	}
+	writeXML(w, http.StatusOK, xmlItems[xmlPerson]{TermsOfUse: termsOfUse, Item: items})
+}
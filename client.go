@@ -26,6 +26,11 @@ type BGG struct {
 	scheme  string
 	client  *http.Client
 	limiter Limiter
+	// location is the BGG server time zone, used for date times like modified since
+	location *time.Location
+	// drift is the schema drift handler, nil means the strict mode is disabled
+	drift func(SchemaDrift)
+	retry RetryPolicy
 
 	// I prefer not to use the cookie jar since this is simpler
 	cookies  []*http.Cookie
@@ -88,11 +93,22 @@ func (bgg *BGG) buildURL(path string, args map[string]string) string {
 }
 
 func (bgg *BGG) do(req *http.Request) (*http.Response, error) {
-	bgg.limiter.Take()
 	if bgg.token != "" {
 		req.Header.Set("Authorization", "Bearer "+bgg.token)
 	}
-	return bgg.client.Do(req)
+
+	for attempt := 0; ; attempt++ {
+		bgg.limiter.Take()
+		resp, err := bgg.client.Do(req)
+		if err != nil || !bgg.retry.shouldRetry(req, resp, attempt) {
+			return resp, err
+		}
+		resp.Body.Close()
+
+		if err := sleep(req.Context(), bgg.retry.backoff(attempt, resp.Header)); err != nil {
+			return nil, err
+		}
+	}
 }
 
 func (bgg *BGG) roundTrip(req *http.Request) (*http.Response, error) {
@@ -146,6 +162,24 @@ func SetLimiter(limiter Limiter) OptionSetter {
 	}
 }
 
+// SetServerLocation sets the BGG server time zone, it is used to convert the date times
+// sent to (and received from) the BGG, default is US Eastern time
+func SetServerLocation(loc *time.Location) OptionSetter {
+	return func(bgg *BGG) {
+		bgg.location = loc
+	}
+}
+
+func defaultServerLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		// No tz database, the daylight saving is ignored
+		return time.FixedZone("EST", -5*60*60)
+	}
+
+	return loc
+}
+
 // NewBGGClient returns a new client
 func NewBGGClient(opt ...OptionSetter) *BGG {
 	result := &BGG{
@@ -154,8 +188,9 @@ func NewBGGClient(opt ...OptionSetter) *BGG {
 		client: &http.Client{
 			Transport: http.DefaultTransport,
 		},
-		lock:    sync.RWMutex{},
-		limiter: noOpLimiter{},
+		lock:     sync.RWMutex{},
+		limiter:  noOpLimiter{},
+		location: defaultServerLocation(),
 	}
 
 	for i := range opt {
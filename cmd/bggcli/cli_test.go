@@ -1,12 +1,14 @@
 package main
 
 import (
-	"context"
+	"encoding/json"
 	"flag"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/fzerorubigd/gobgg"
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -16,29 +18,50 @@ func (devNull) Write(in []byte) (int, error) {
 	return len(in), nil
 }
 
+// capture runs the entry point without the user config and returns the exit code and the output
+func capture(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("BGGCLI_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("XDG_CACHE_HOME", dir)
+	for _, env := range []string{"BGG_TOKEN", "BGG_USERNAME", "BGG_PROXY", "BGG_TIMEOUT", "BGG_PASSWORD"} {
+		t.Setenv(env, "")
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	saved := os.Stdout
+	os.Stdout = w
+	code := run(args)
+	os.Stdout = saved
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return code, string(out)
+}
+
 func TestDispatch(t *testing.T) {
-	bgg := gobgg.NewBGGClient()
-	var (
-		err  error
-		args []string
-	)
-
-	commands = nil
-	addCommand("test", "test command", func(ctx context.Context, funcBGG *gobgg.BGG, funcArgs ...string) error {
-		require.True(t, len(funcArgs) > 1)
-		require.Equal(t, "test", args[0])
-		require.Equal(t, bgg, funcBGG)
-		require.Equal(t, args, funcArgs)
-		return err
-	})
-
-	ctx := context.Background()
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/user",
+		httpmock.NewStringResponder(200, `<user id="1000001" name="gobgg"><firstname value="Go" /></user>`))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		httpmock.NewStringResponder(500, "server error"))
+
 	flag.CommandLine.SetOutput(&devNull{})
-	args = []string{"test", "arg1", "arg2"}
-	require.NoError(t, dispatch(ctx, bgg, args...))
-	require.Error(t, dispatch(ctx, bgg, "invalid", "arg"))
-	require.Error(t, dispatch(ctx, bgg))
+	code, out := capture(t, "-format", "json", "user", "gobgg")
+	require.Equal(t, 0, code)
+	var user map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &user))
+	require.Equal(t, "gobgg", user["user_name"])
 
-	err = io.EOF
-	require.Error(t, dispatch(ctx, bgg, args...))
+	code, _ = capture(t, "invalid", "arg")
+	require.Equal(t, 2, code)
+	code, _ = capture(t)
+	require.Equal(t, 2, code)
+	code, _ = capture(t, "thing", "13")
+	require.Equal(t, 1, code)
 }
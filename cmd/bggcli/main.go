@@ -1,33 +1,16 @@
 package main
 
 import (
-	"context"
-	"flag"
-	"log"
-	"os/signal"
-	"syscall"
+	"os"
 
-	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/cmd/internal/cli"
 )
 
 func main() {
-	ctx, cnl := signal.NotifyContext(
-		context.Background(),
-		syscall.SIGKILL,
-		syscall.SIGINT,
-		syscall.SIGTERM,
-		syscall.SIGQUIT,
-		syscall.SIGABRT,
-	)
-	defer cnl()
-
-	// TODO: Add option to customize the http client
-	flag.Usage = usage
-	flag.Parse()
-
-	bgg := gobgg.NewBGGClient()
+	os.Exit(run(os.Args[1:]))
+}
 
-	if err := dispatch(ctx, bgg, flag.Args()...); err != nil {
-		log.Fatal(err.Error())
-	}
+// run returns the exit code, it is the entry point for the tests
+func run(args []string) int {
+	return cli.Main(args)
 }
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// capture runs the entry point without the user config and returns the exit code and the output
+func capture(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("BGGCLI_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("XDG_CACHE_HOME", dir)
+	for _, env := range []string{"BGG_TOKEN", "BGG_USERNAME", "BGG_PROXY", "BGG_TIMEOUT", "BGG_PASSWORD"} {
+		t.Setenv(env, "")
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	saved := os.Stdout
+	os.Stdout = w
+	code := run(args)
+	os.Stdout = saved
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return code, string(out)
+}
+
+func TestRun(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	fixture, err := os.ReadFile("../../testdata/collection.xml")
+	require.NoError(t, err)
+	httpmock.RegisterResponderWithQuery("GET", "https://boardgamegeek.com/xmlapi2/collection",
+		"username=gobgg&own=1", httpmock.NewBytesResponder(200, fixture))
+
+	// The global flags are accepted along with the command flags
+	code, out := capture(t, "-format", "json", "-own", "gobgg")
+	require.Equal(t, 0, code)
+	var items []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &items))
+	require.Len(t, items, 4)
+	assert.Equal(t, "Hokm", items[0]["name"])
+
+	code, _ = capture(t, "-format", "{{", "gobgg")
+	assert.Equal(t, 2, code)
+	code, _ = capture(t, "-invalid")
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateFormat = "2006-01-02"
+
+// requiredUsername returns the username flag, the first argument or the default username, which
+// is the one in the config or the logged in user
+func (e *env) requiredUsername(flagValue string, args []string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if len(args) > 0 && args[0] != "" {
+		return args[0], nil
+	}
+	if e.cfg != nil && e.cfg.Username != "" {
+		return e.cfg.Username, nil
+	}
+	if username := e.bgg.GetActiveUsername(); username != "" {
+		return username, nil
+	}
+
+	return "", fmt.Errorf("%w: username is required", errUsage)
+}
+
+// requiredID parses the first argument as the id
+func requiredID(args []string) (int64, error) {
+	idStr := strings.Join(args, " ")
+	id, err := strconv.ParseInt(idStr, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%w: the argument should be an integer, but is %q", errUsage, idStr)
+	}
+
+	return id, nil
+}
+
+// optionalDate parses the date flag, empty is the zero time
+func optionalDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(dateFormat, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s date %q, the format is %s", errUsage, name, value, dateFormat)
+	}
+
+	return t, nil
+}
+
+func yearString(year int) string {
+	if year > 0 {
+		return fmt.Sprint(year)
+	}
+
+	return "Not specified"
+}
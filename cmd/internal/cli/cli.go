@@ -0,0 +1,339 @@
+// Package cli is the bggcli commands, it is shared between the bggcli and the standalone
+// binaries that are aliases for a single command
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// Exit codes
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// errUsage is for the invalid arguments, it ends with the ExitUsage code
+var errUsage = errors.New("invalid usage")
+
+var (
+	commands []command
+	lock     sync.RWMutex
+)
+
+// env is what the commands need to run
+type env struct {
+	bgg *gobgg.BGG
+	out *printer
+	// in is for the prompts and the confirmations
+	in *bufio.Reader
+	// stdin is the file behind in, it is used to read the password without the echo when it
+	// is a terminal, nil in the tests
+	stdin *os.File
+	cfg   *config
+}
+
+// runFunc runs the command with the positional args, the flags are already parsed
+type runFunc func(ctx context.Context, e *env, args ...string) error
+
+// argKind is the kind of the positional args, it is used in the completion
+type argKind int
+
+const (
+	argNone argKind = iota
+	argUsername
+	argGame
+	argCommand
+	argShell
+)
+
+type command struct {
+	Name        string
+	Aliases     []string
+	Description string
+	// Args is the positional args in the help, like <username>
+	Args     string
+	Complete argKind
+	// Hidden commands are not in the usage and the completion
+	Hidden bool
+
+	// Setup defines the flags and returns the function that runs the command, it is also
+	// called without running the command for the help and the completion
+	Setup func(*flag.FlagSet) runFunc
+}
+
+// commandOption is the optional settings of a command
+type commandOption func(*command)
+
+// withAliases sets the other names of the command
+func withAliases(aliases ...string) commandOption {
+	return func(c *command) {
+		c.Aliases = append(c.Aliases, aliases...)
+	}
+}
+
+// withArgs sets the positional args help and how they are completed
+func withArgs(args string, kind argKind) commandOption {
+	return func(c *command) {
+		c.Args = args
+		c.Complete = kind
+	}
+}
+
+// hidden removes the command from the usage and the completion
+func hidden() commandOption {
+	return func(c *command) {
+		c.Hidden = true
+	}
+}
+
+func (c *command) is(name string) bool {
+	return c.Name == name || slices.Contains(c.Aliases, name)
+}
+
+func (c *command) flagSet() (*flag.FlagSet, runFunc) {
+	fs := flag.NewFlagSet(c.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	run := c.Setup(fs)
+
+	return fs, run
+}
+
+// help writes the command usage, the description and the flags
+func (c *command) help(w io.Writer) {
+	fs, _ := c.flagSet()
+	fmt.Fprintf(w, "Usage: %s %s [flags] %s\n\n%s\n", filepath.Base(os.Args[0]), c.Name, c.Args, c.Description)
+	if len(c.Aliases) > 0 {
+		fmt.Fprintf(w, "\nAliases: %s\n", strings.Join(c.Aliases, ", "))
+	}
+
+	hasFlags := false
+	fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+	if hasFlags {
+		fmt.Fprintln(w, "\nFlags:")
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+	}
+}
+
+// globalFlags defines the flags that are shared between all the commands
+func globalFlags(fs *flag.FlagSet, format, configPath *string) {
+	fs.StringVar(format, "format", formatTable,
+		"output format, table, json, jsonl, csv, yaml or a text/template executed for each item")
+	fs.StringVar(configPath, "config", "", fmt.Sprintf("the config file, default is %q", defaultConfigPath()))
+}
+
+func usage() {
+	printUsage(flag.CommandLine.Output())
+}
+
+func printUsage(w io.Writer) {
+	// usage is also called in dispatch, but multiple read lock is fine
+	lock.RLock()
+	defer lock.RUnlock()
+
+	fmt.Fprintf(w, "Usage of %s:\n", os.Args[0])
+	var format, configPath string
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	globalFlags(fs, &format, &configPath)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+
+	fmt.Fprintf(w, "Sub commands:\n")
+
+	for i := range commands {
+		if commands[i].Hidden {
+			continue
+		}
+		name := commands[i].Name
+		if len(commands[i].Aliases) > 0 {
+			name += " (" + strings.Join(commands[i].Aliases, ", ") + ")"
+		}
+		fmt.Fprintf(w, "  %s: %s\n", name, commands[i].Description)
+	}
+	fmt.Fprintf(w, "Run \"%s help <command>\" for the command flags\n", filepath.Base(os.Args[0]))
+	fmt.Fprintf(w, "Exit codes: %d on success, %d on error and %d on invalid usage\n",
+		ExitOK, ExitError, ExitUsage)
+}
+
+// findCommand returns the command by its name or alias, the caller should hold the lock
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].is(name) {
+			return &commands[i]
+		}
+	}
+
+	return nil
+}
+
+func dispatch(ctx context.Context, e *env, args ...string) error {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if len(args) < 1 {
+		usage()
+		return fmt.Errorf("%w: atleast one arg is required", errUsage)
+	}
+
+	c := findCommand(args[0])
+	if c == nil {
+		usage()
+		return fmt.Errorf("%w: invalid command %q", errUsage, args[0])
+	}
+
+	fs, run := c.flagSet()
+	if err := fs.Parse(args[1:]); err != nil {
+		c.help(flag.CommandLine.Output())
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	return run(ctx, e, fs.Args()...)
+}
+
+func addCommand(name, description string, setup func(*flag.FlagSet) runFunc, opts ...commandOption) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	c := command{
+		Name:        name,
+		Description: description,
+		Setup:       setup,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	commands = append(commands, c)
+}
+
+// Main parses the global flags and runs the sub command, it returns the exit code
+func Main(args []string) int {
+	ctx, cnl := notifyContext()
+	defer cnl()
+
+	var format, configPath string
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	globalFlags(fs, &format, &configPath)
+	fs.Usage = usage
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	return exitCode(run(ctx, format, configPath, fs.Args()...))
+}
+
+// Alias runs a single command as a standalone binary, the global flags are accepted along with the
+// command flags, it returns the exit code
+func Alias(name string, args []string) int {
+	ctx, cnl := notifyContext()
+	defer cnl()
+
+	lock.RLock()
+	c := findCommand(name)
+	lock.RUnlock()
+	if c == nil {
+		return exitCode(fmt.Errorf("%w: invalid command %q", errUsage, name))
+	}
+
+	var format, configPath string
+	fs, cmd := c.flagSet()
+	globalFlags(fs, &format, &configPath)
+	if err := fs.Parse(args); err != nil {
+		c.help(flag.CommandLine.Output())
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return exitCode(fmt.Errorf("%w: %w", errUsage, err))
+	}
+
+	e, err := newEnv(format, configPath)
+	if err != nil {
+		return exitCode(err)
+	}
+
+	return exitCode(cmd(ctx, e, fs.Args()...))
+}
+
+func notifyContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(
+		context.Background(),
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+		syscall.SIGABRT,
+	)
+}
+
+// exitCode prints the error and returns the exit code for it
+func exitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	fmt.Fprintln(os.Stderr, err.Error())
+	if errors.Is(err, errUsage) {
+		return ExitUsage
+	}
+
+	return ExitError
+}
+
+func run(ctx context.Context, format, configPath string, args ...string) error {
+	e, err := newEnv(format, configPath)
+	if err != nil {
+		return err
+	}
+
+	return dispatch(ctx, e, args...)
+}
+
+// newEnv loads the config and creates the client and the printer
+func newEnv(format, configPath string) (*env, error) {
+	explicit := configPath != ""
+	if !explicit {
+		configPath = defaultConfigPath()
+	}
+	cfg, err := loadConfig(configPath, explicit, os.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := cfg.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := newPrinter(os.Stdout, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &env{
+		bgg:   gobgg.NewBGGClient(opts...),
+		out:   out,
+		in:    bufio.NewReader(os.Stdin),
+		stdin: os.Stdin,
+		cfg:   cfg,
+	}, nil
+}
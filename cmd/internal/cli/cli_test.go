@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/require"
+)
+
+type devNull struct{}
+
+func (devNull) Write(in []byte) (int, error) {
+	return len(in), nil
+}
+
+func newTestEnv(t *testing.T, w io.Writer, format, input string) *env {
+	t.Helper()
+	out, err := newPrinter(w, format)
+	require.NoError(t, err)
+
+	return &env{
+		bgg: gobgg.NewBGGClient(),
+		out: out,
+		in:  bufio.NewReader(strings.NewReader(input)),
+		cfg: &config{CacheDir: t.TempDir()},
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	e := newTestEnv(t, io.Discard, formatTable, "")
+	var (
+		err  error
+		args []string
+	)
+
+	saved := commands
+	defer func() { commands = saved }()
+	commands = nil
+	var verbose bool
+	addCommand("test", "test command", func(fs *flag.FlagSet) runFunc {
+		fs.BoolVar(&verbose, "v", false, "verbose")
+		return func(ctx context.Context, funcEnv *env, funcArgs ...string) error {
+			require.Same(t, e, funcEnv)
+			require.Equal(t, []string{"arg1", "arg2"}, funcArgs)
+			return err
+		}
+	}, withAliases("t"))
+
+	ctx := context.Background()
+	flag.CommandLine.SetOutput(&devNull{})
+	args = []string{"test", "-v", "arg1", "arg2"}
+	require.NoError(t, dispatch(ctx, e, args...))
+	require.True(t, verbose)
+	require.NoError(t, dispatch(ctx, e, "t", "arg1", "arg2"))
+	require.NoError(t, dispatch(ctx, e, "test", "-h"))
+	require.ErrorIs(t, dispatch(ctx, e, "test", "-invalid"), errUsage)
+	require.Error(t, dispatch(ctx, e, "invalid", "arg"))
+	require.Error(t, dispatch(ctx, e))
+
+	err = io.EOF
+	require.Error(t, dispatch(ctx, e, args...))
+}
+
+func TestArgs(t *testing.T) {
+	e := newTestEnv(t, io.Discard, formatTable, "")
+	_, err := e.requiredUsername("", nil)
+	require.ErrorIs(t, err, errUsage)
+	username, err := e.requiredUsername("", []string{"gobgg"})
+	require.NoError(t, err)
+	require.Equal(t, "gobgg", username)
+	username, err = e.requiredUsername("flag", []string{"gobgg"})
+	require.NoError(t, err)
+	require.Equal(t, "flag", username)
+
+	_, err = requiredID([]string{"abc"})
+	require.ErrorIs(t, err, errUsage)
+	id, err := requiredID([]string{"13"})
+	require.NoError(t, err)
+	require.Equal(t, int64(13), id)
+
+	_, err = optionalDate("min", "01/02/2024")
+	require.ErrorIs(t, err, errUsage)
+	d, err := optionalDate("min", "")
+	require.NoError(t, err)
+	require.True(t, d.IsZero())
+}
+
+func TestCommandUsageErrors(t *testing.T) {
+	ctx := context.Background()
+	e := newTestEnv(t, io.Discard, formatTable, "")
+	for _, args := range [][]string{
+		{"collection"},
+		{"plays", "-min-date", "yesterday", "gobgg"},
+		{"plays", "-export", "xml", "gobgg"},
+		{"user"},
+		{"person", "abc"},
+		{"geeklist"},
+		{"rankbreakdown", "x"},
+		{"trends", "-list", "invalid"},
+	} {
+		err := dispatch(ctx, e, args...)
+		require.ErrorIs(t, err, errUsage, args)
+		require.NotContains(t, err.Error(), "invalid command", args)
+	}
+}
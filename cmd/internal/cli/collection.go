@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/export"
+)
+
+var allCollectionTypes = []gobgg.CollectionType{
+	gobgg.CollectionTypeOwn,
+	gobgg.CollectionTypeRated,
+	gobgg.CollectionTypePlayed,
+	gobgg.CollectionTypeComment,
+	gobgg.CollectionTypeTrade,
+	gobgg.CollectionTypeWant,
+	gobgg.CollectionTypeWishList,
+	gobgg.CollectionTypePreorder,
+	gobgg.CollectionTypeWantToPlay,
+	gobgg.CollectionTypeWantToBuy,
+	gobgg.CollectionTypePrevOwned,
+	gobgg.CollectionTypeHasParts,
+	gobgg.CollectionTypeWantParts,
+}
+
+func collection(cmd *flag.FlagSet) runFunc {
+	var (
+		username string
+		stats    bool
+		exp      string
+		types    = make(map[gobgg.CollectionType]*bool)
+	)
+	cmd.StringVar(&username, "username", "", "the username, it can be the argument too")
+	cmd.BoolVar(&stats, "stats", false, "include the rating and ranking stats")
+	cmd.StringVar(&exp, "export", "", "export in the BGG collection CSV layout, the only valid value is bgg")
+	for _, ct := range allCollectionTypes {
+		types[ct] = cmd.Bool(string(ct), false, fmt.Sprintf("Include %q items", ct))
+	}
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		username, err := e.requiredUsername(username, args)
+		if err != nil {
+			return err
+		}
+
+		if exp != "" && exp != "bgg" {
+			return fmt.Errorf("%w: invalid export %q", errUsage, exp)
+		}
+
+		var opt []gobgg.CollectionType
+		for _, ct := range allCollectionTypes {
+			if *types[ct] {
+				opt = append(opt, ct)
+			}
+		}
+
+		items, err := e.bgg.GetCollection(ctx, username,
+			gobgg.SetCollectionTypes(opt...), gobgg.SetStats(stats || exp == "bgg"))
+		if err != nil {
+			return err
+		}
+
+		if exp == "bgg" {
+			return export.CollectionCSV(e.out.w, items)
+		}
+
+		return e.out.print(items, []string{"ID", "Name", "Year Published", "Status"}, func(t *table) {
+			for i := range items {
+				t.row(items[i].ID, items[i].Name, yearString(items[i].YearPublished),
+					strings.Join(items[i].CollectionStatus, ","))
+			}
+		})
+	}
+}
+
+func init() {
+	addCommand("collection", "Get the user collection", collection,
+		withAliases("coll"), withArgs("<username>", argUsername))
+}
@@ -0,0 +1,356 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+const (
+	gamesCacheFile = "games.json"
+	gamesCacheTTL  = 7 * 24 * time.Hour
+	// gamesMinPrefix is the minimum length of the game name before calling the search
+	gamesMinPrefix   = 3
+	completeTimeout  = 5 * time.Second
+	completeCommand  = "__complete"
+	globalFlagFormat = "-format"
+	globalFlagConfig = "-config"
+)
+
+var shells = []string{"bash", "zsh", "fish"}
+
+const bashCompletion = `# bash completion for %[1]s, add this to ~/.bashrc:
+#   source <(%[1]s completion bash)
+_%[2]s_complete() {
+	local IFS=$'\n'
+	COMPREPLY=($(%[1]s %[3]s -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -F _%[2]s_complete %[1]s
+`
+
+const zshCompletion = `#compdef %[1]s
+# zsh completion for %[1]s, add this to ~/.zshrc after compinit:
+#   source <(%[1]s completion zsh)
+_%[2]s() {
+	local -a candidates
+	candidates=(${(f)"$(%[1]s %[3]s -- "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+	compadd -U -- "${candidates[@]}"
+}
+compdef _%[2]s %[1]s
+`
+
+const fishCompletion = `# fish completion for %[1]s, save it as ~/.config/fish/completions/%[1]s.fish
+function __%[2]s_complete
+	set -l tokens (commandline -opc)
+	%[1]s %[3]s -- $tokens[2..-1] "$(commandline -ct)" 2>/dev/null
+end
+complete -c %[1]s -f -a '(__%[2]s_complete)'
+`
+
+var nonIdentifier = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// gameCacheEntry is the cached search result for a game name prefix
+type gameCacheEntry struct {
+	Time  time.Time `json:"time"`
+	Names []string  `json:"names"`
+}
+
+func (cfg *config) gamesCachePath() string {
+	if cfg.CacheDir == "" {
+		return ""
+	}
+
+	return filepath.Join(cfg.CacheDir, gamesCacheFile)
+}
+
+func (cfg *config) loadGamesCache() map[string]gameCacheEntry {
+	cache := make(map[string]gameCacheEntry)
+	path := cfg.gamesCachePath()
+	if path == "" {
+		return cache
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	// A broken cache is the same as no cache
+	_ = json.Unmarshal(data, &cache)
+
+	return cache
+}
+
+func (cfg *config) saveGamesCache(cache map[string]gameCacheEntry) error {
+	path := cfg.gamesCachePath()
+	if path == "" {
+		return nil
+	}
+
+	for key := range cache {
+		if time.Since(cache[key].Time) > gamesCacheTTL {
+			delete(cache, key)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create cache dir failed: %w", err)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("marshal games cache failed: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write games cache failed: %w", err)
+	}
+
+	return nil
+}
+
+func filterPrefix(candidates []string, prefix string) []string {
+	prefix = strings.ToLower(prefix)
+	var result []string
+	for _, c := range candidates {
+		if strings.HasPrefix(strings.ToLower(c), prefix) && !slices.Contains(result, c) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// gameNames returns the game names that start with the prefix, the search results are cached
+// and a cached shorter prefix is used for the longer ones
+func (e *env) gameNames(ctx context.Context, prefix string) ([]string, error) {
+	if len([]rune(prefix)) < gamesMinPrefix {
+		return nil, nil
+	}
+
+	key := strings.ToLower(prefix)
+	cache := e.cfg.loadGamesCache()
+	for cached, entry := range cache {
+		if strings.HasPrefix(key, cached) && time.Since(entry.Time) <= gamesCacheTTL {
+			return filterPrefix(entry.Names, prefix), nil
+		}
+	}
+
+	result, err := e.bgg.Search(ctx, prefix, gobgg.SearchTypes(gobgg.BoardGameType, gobgg.BoardGameExpansionType))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(result))
+	for i := range result {
+		names = append(names, result[i].Name)
+	}
+	names = filterPrefix(names, prefix)
+
+	cache[key] = gameCacheEntry{Time: time.Now(), Names: names}
+	if err := e.cfg.saveGamesCache(cache); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (e *env) usernames() []string {
+	var result []string
+	for _, name := range []string{e.cfg.Username, e.bgg.GetActiveUsername()} {
+		if name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// flagValue returns the flag if the word is a flag that needs a value in the next word
+func flagValue(fs *flag.FlagSet, word string) (*flag.Flag, bool) {
+	if !strings.HasPrefix(word, "-") || strings.Contains(word, "=") {
+		return nil, false
+	}
+
+	f := fs.Lookup(strings.TrimLeft(word, "-"))
+	if f == nil {
+		return nil, false
+	}
+
+	if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+		return nil, false
+	}
+
+	return f, true
+}
+
+func flagNames(fs *flag.FlagSet) []string {
+	var result []string
+	fs.VisitAll(func(f *flag.Flag) {
+		result = append(result, "-"+f.Name)
+	})
+
+	return result
+}
+
+func (e *env) completeArg(ctx context.Context, kind argKind, current string) ([]string, error) {
+	switch kind {
+	case argUsername:
+		return filterPrefix(e.usernames(), current), nil
+	case argGame:
+		return e.gameNames(ctx, current)
+	case argCommand:
+		return filterPrefix(commandNames(), current), nil
+	case argShell:
+		return filterPrefix(shells, current), nil
+	}
+
+	return nil, nil
+}
+
+// commandNames returns the sorted names and aliases of the visible commands, the caller should hold the lock
+func commandNames() []string {
+	var result []string
+	for i := range commands {
+		if commands[i].Hidden {
+			continue
+		}
+		result = append(result, commands[i].Name)
+		result = append(result, commands[i].Aliases...)
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// complete returns the candidates for the last word, the words are the command line without the
+// program name and the last one is the word under the cursor (it can be empty)
+func (e *env) complete(ctx context.Context, words ...string) ([]string, error) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current, words := words[len(words)-1], words[:len(words)-1]
+
+	global := flag.NewFlagSet("global", flag.ContinueOnError)
+	global.String(strings.TrimPrefix(globalFlagFormat, "-"), "", "")
+	global.String(strings.TrimPrefix(globalFlagConfig, "-"), "", "")
+
+	i := 0
+	for ; i < len(words) && strings.HasPrefix(words[i], "-"); i++ {
+		if _, ok := flagValue(global, words[i]); ok {
+			i++
+		}
+	}
+
+	switch {
+	case i > len(words):
+		// The value of a global flag
+		return nil, nil
+	case i == len(words) && strings.HasPrefix(current, "-"):
+		return filterPrefix(flagNames(global), current), nil
+	case i == len(words):
+		return filterPrefix(commandNames(), current), nil
+	}
+
+	c := findCommand(words[i])
+	if c == nil {
+		return nil, nil
+	}
+
+	fs, _ := c.flagSet()
+	if rest := words[i+1:]; len(rest) > 0 {
+		if f, ok := flagValue(fs, rest[len(rest)-1]); ok {
+			// The game flag is an id in some commands, only the string flags are names
+			isString := false
+			if getter, ok := f.Value.(flag.Getter); ok {
+				_, isString = getter.Get().(string)
+			}
+			switch {
+			case f.Name == "username":
+				return e.completeArg(ctx, argUsername, current)
+			case f.Name == "game" && isString:
+				return e.completeArg(ctx, argGame, current)
+			}
+			return nil, nil
+		}
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return filterPrefix(flagNames(fs), current), nil
+	}
+
+	return e.completeArg(ctx, c.Complete, current)
+}
+
+func completeCmd(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args ...string) error {
+		ctx, cnl := context.WithTimeout(ctx, completeTimeout)
+		defer cnl()
+
+		// The errors are ignored, the completion should not print anything else
+		candidates, _ := e.complete(ctx, args...)
+		for _, c := range candidates {
+			fmt.Fprintln(e.out.w, c)
+		}
+
+		return nil
+	}
+}
+
+func completion(*flag.FlagSet) runFunc {
+	return func(_ context.Context, e *env, args ...string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("%w: the shell is required, %s", errUsage, strings.Join(shells, ", "))
+		}
+
+		prog := filepath.Base(os.Args[0])
+		name := nonIdentifier.ReplaceAllString(prog, "_")
+
+		var script string
+		switch args[0] {
+		case "bash":
+			script = bashCompletion
+		case "zsh":
+			script = zshCompletion
+		case "fish":
+			script = fishCompletion
+		default:
+			return fmt.Errorf("%w: invalid shell %q", errUsage, args[0])
+		}
+
+		_, err := fmt.Fprintf(e.out.w, script, prog, name, completeCommand)
+		return err
+	}
+}
+
+func help(*flag.FlagSet) runFunc {
+	return func(_ context.Context, e *env, args ...string) error {
+		if len(args) == 0 {
+			printUsage(e.out.w)
+			return nil
+		}
+
+		c := findCommand(args[0])
+		if c == nil || c.Hidden {
+			return fmt.Errorf("%w: invalid command %q", errUsage, args[0])
+		}
+
+		c.help(e.out.w)
+		return nil
+	}
+}
+
+func init() {
+	addCommand("help", "Show the help of a command", help, withArgs("[command]", argCommand))
+	addCommand("completion", "Generate the shell completion script", completion,
+		withArgs("<bash|zsh|fish>", argShell))
+	addCommand(completeCommand, "Print the completion candidates", completeCmd, hidden())
+}
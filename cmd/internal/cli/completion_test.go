@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelp(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	e := newTestEnv(t, &buf, formatTable, "")
+
+	require.NoError(t, dispatch(ctx, e, "help", "coll"))
+	out := buf.String()
+	assert.Contains(t, out, "collection [flags] <username>")
+	assert.Contains(t, out, "Aliases: coll")
+	assert.Contains(t, out, "-stats")
+
+	buf.Reset()
+	require.NoError(t, dispatch(ctx, e, "help"))
+	assert.Contains(t, buf.String(), "rankbreakdown (breakdown)")
+	assert.NotContains(t, buf.String(), completeCommand)
+
+	require.ErrorIs(t, dispatch(ctx, e, "help", "invalid"), errUsage)
+	require.ErrorIs(t, dispatch(ctx, e, "help", completeCommand), errUsage)
+}
+
+func TestCompletionScripts(t *testing.T) {
+	ctx := context.Background()
+	for _, shell := range shells {
+		var buf bytes.Buffer
+		require.NoError(t, dispatch(ctx, newTestEnv(t, &buf, formatTable, ""), "completion", shell))
+		assert.Contains(t, buf.String(), completeCommand+" --", shell)
+	}
+
+	require.ErrorIs(t, dispatch(ctx, newTestEnv(t, io.Discard, formatTable, ""), "completion", "csh"), errUsage)
+}
+
+func runComplete(t *testing.T, e *env, words ...string) []string {
+	t.Helper()
+	var buf bytes.Buffer
+	e.out.w = &buf
+	require.NoError(t, dispatch(context.Background(), e, append([]string{completeCommand, "--"}, words...)...))
+
+	return strings.Fields(strings.ReplaceAll(buf.String(), " ", "_"))
+}
+
+func TestComplete(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/search",
+		httpmock.NewStringResponder(200, searchFixture))
+
+	e := newTestEnv(t, io.Discard, formatTable, "")
+	e.cfg.Username = "gobgg"
+
+	assert.Equal(t, []string{"rankbreakdown", "rankings", "rate"}, runComplete(t, e, "ra"))
+	assert.Equal(t, []string{"-format"}, runComplete(t, e, "-f"))
+	assert.Empty(t, runComplete(t, e, "-format", ""))
+	assert.Equal(t, []string{"hot"}, runComplete(t, e, "-format", "json", "ho"))
+	assert.Equal(t, []string{"-stats"}, runComplete(t, e, "collection", "-st"))
+	assert.Equal(t, []string{"gobgg"}, runComplete(t, e, "coll", "-own", ""))
+	assert.Equal(t, []string{"gobgg"}, runComplete(t, e, "plays", "-username", "go"))
+	assert.Equal(t, []string{"zsh"}, runComplete(t, e, "completion", "z"))
+	assert.Equal(t, []string{"thing"}, runComplete(t, e, "help", "th"))
+
+	// The game names are searched once, the longer prefix uses the cache
+	assert.Equal(t, []string{"CATAN", "Catan_Card_Game"}, runComplete(t, e, "log-play", "cat"))
+	assert.Equal(t, []string{"Catan_Card_Game"}, runComplete(t, e, "log-play", "-game", "Catan C"))
+	assert.Empty(t, runComplete(t, e, "search", "ca"))
+	assert.Empty(t, runComplete(t, e, "plays", "-game", "catan"))
+	assert.Empty(t, runComplete(t, e, "log-play", "-player", "catan"))
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
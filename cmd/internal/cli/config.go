@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"gopkg.in/yaml.v3"
+)
+
+// The environment variables, they override the config file
+const (
+	envConfig    = "BGGCLI_CONFIG"
+	envToken     = "BGG_TOKEN"
+	envUsername  = "BGG_USERNAME"
+	envRateLimit = "BGG_RATE_LIMIT"
+	envProxy     = "BGG_PROXY"
+	envTimeout   = "BGG_TIMEOUT"
+	envCacheDir  = "BGG_CACHE_DIR"
+	envPassword  = "BGG_PASSWORD"
+)
+
+const sessionFile = "session.json"
+
+// config is the bggcli settings, from the config file and the environment variables
+type config struct {
+	// Token is the bearer token for the BGG API
+	Token string `yaml:"token"`
+	// Username is the default username for the commands that need one
+	Username string `yaml:"username"`
+	// RateLimit is the maximum number of requests per second, zero means no limit
+	RateLimit float64 `yaml:"rate_limit"`
+	// Proxy is the proxy URL, the default is the HTTP_PROXY and HTTPS_PROXY variables
+	Proxy   string        `yaml:"proxy"`
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheDir keeps the login session and the other cached data
+	CacheDir string `yaml:"cache_dir"`
+}
+
+// session is the stored login cookies
+type session struct {
+	Username string         `json:"username"`
+	Cookies  []*http.Cookie `json:"cookies"`
+}
+
+// defaultConfigPath is the XDG config path, $XDG_CONFIG_HOME/bggcli/config.yaml
+func defaultConfigPath() string {
+	if path := os.Getenv(envConfig); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "bggcli", "config.yaml")
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "bggcli")
+}
+
+// loadConfig reads the config file, a missing file is not an error unless the path is set
+// explicitly, then the environment variables are applied
+func loadConfig(path string, explicit bool, getenv func(string) string) (*config, error) {
+	cfg := &config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, cfg); err != nil {
+				return nil, fmt.Errorf("parse config %q failed: %w", path, err)
+			}
+		case errors.Is(err, os.ErrNotExist) && !explicit:
+		default:
+			return nil, fmt.Errorf("read config failed: %w", err)
+		}
+	}
+
+	if err := cfg.applyEnv(getenv); err != nil {
+		return nil, err
+	}
+
+	if cfg.CacheDir == "" {
+		cfg.CacheDir = defaultCacheDir()
+	}
+
+	return cfg, nil
+}
+
+func (cfg *config) applyEnv(getenv func(string) string) error {
+	if v := getenv(envToken); v != "" {
+		cfg.Token = v
+	}
+	if v := getenv(envUsername); v != "" {
+		cfg.Username = v
+	}
+	if v := getenv(envProxy); v != "" {
+		cfg.Proxy = v
+	}
+	if v := getenv(envCacheDir); v != "" {
+		cfg.CacheDir = v
+	}
+	if v := getenv(envRateLimit); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", envRateLimit, v, err)
+		}
+		cfg.RateLimit = rate
+	}
+	if v := getenv(envTimeout); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", envTimeout, v, err)
+		}
+		cfg.Timeout = timeout
+	}
+
+	return nil
+}
+
+func (cfg *config) sessionPath() string {
+	if cfg.CacheDir == "" {
+		return ""
+	}
+
+	return filepath.Join(cfg.CacheDir, sessionFile)
+}
+
+func (cfg *config) loadSession() (*session, error) {
+	path := cfg.sessionPath()
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read session failed: %w", err)
+	}
+
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse session failed: %w", err)
+	}
+
+	return &s, nil
+}
+
+func (cfg *config) saveSession(s *session) error {
+	path := cfg.sessionPath()
+	if path == "" {
+		return errors.New("no cache directory to store the session")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create cache directory failed: %w", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("encoding session failed: %w", err)
+	}
+
+	// The cookies are the credentials, only the user can read them
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write session failed: %w", err)
+	}
+
+	return nil
+}
+
+// clientOptions creates the client options based on the config and the stored session
+func (cfg *config) clientOptions() ([]gobgg.OptionSetter, error) {
+	transport := http.DefaultTransport
+	if cfg.Proxy != "" {
+		u, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: %w", cfg.Proxy, err)
+		}
+
+		def, ok := http.DefaultTransport.(*http.Transport)
+		if !ok {
+			return nil, errors.New("the proxy is not supported with a custom default transport")
+		}
+		proxied := def.Clone()
+		proxied.Proxy = http.ProxyURL(u)
+		transport = proxied
+	}
+
+	opts := []gobgg.OptionSetter{
+		gobgg.SetClient(&http.Client{
+			Transport: transport,
+			Timeout:   cfg.Timeout,
+		}),
+	}
+	if cfg.Token != "" {
+		opts = append(opts, gobgg.SetAuthToken(cfg.Token))
+	}
+	if cfg.RateLimit > 0 {
+		opts = append(opts, gobgg.SetLimiter(newRateLimiter(cfg.RateLimit)))
+	}
+
+	s, err := cfg.loadSession()
+	if err != nil {
+		return nil, err
+	}
+	if s != nil {
+		opts = append(opts, gobgg.SetCookies(s.Username, s.Cookies))
+	}
+
+	return opts, nil
+}
+
+// rateLimiter is a simple limiter that spaces the requests evenly
+type rateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+	}
+}
+
+func (rl *rateLimiter) Take() time.Time {
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+
+	now := time.Now()
+	if rl.next.After(now) {
+		time.Sleep(rl.next.Sub(now))
+		now = rl.next
+	}
+	rl.next = now.Add(rl.interval)
+
+	return now
+}
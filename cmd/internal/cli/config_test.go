@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`token: file-token
+username: fzerorubigd
+rate_limit: 2
+timeout: 30s
+cache_dir: /tmp/bggcli
+`), 0o600))
+
+	env := map[string]string{
+		envToken:   "env-token",
+		envTimeout: "1m",
+	}
+	cfg, err := loadConfig(path, true, func(key string) string { return env[key] })
+	require.NoError(t, err)
+	assert.Equal(t, &config{
+		Token:     "env-token",
+		Username:  "fzerorubigd",
+		RateLimit: 2,
+		Timeout:   time.Minute,
+		CacheDir:  "/tmp/bggcli",
+	}, cfg)
+
+	_, err = loadConfig(filepath.Join(dir, "missing.yaml"), true, os.Getenv)
+	require.Error(t, err)
+	cfg, err = loadConfig(filepath.Join(dir, "missing.yaml"), false, func(string) string { return "" })
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Token)
+
+	env[envRateLimit] = "fast"
+	_, err = loadConfig(path, true, func(key string) string { return env[key] })
+	require.Error(t, err)
+}
+
+func TestLogin(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "https://boardgamegeek.com/login/api/v1",
+		func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Credentials map[string]string `json:"credentials"`
+			}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&payload))
+			assert.Equal(t, "secret", payload.Credentials["password"])
+
+			resp := httpmock.NewStringResponse(204, "")
+			resp.Header.Add("Set-Cookie", "bggusername=gobgg; Path=/")
+			resp.Header.Add("Set-Cookie", "SessionID=abcd; Path=/")
+			return resp, nil
+		})
+
+	var buf bytes.Buffer
+	e := newTestEnv(t, &buf, formatTable, "secret\n")
+	require.NoError(t, dispatch(context.Background(), e, "login", "gobgg"))
+	assert.Equal(t, "Logged in as gobgg\n", buf.String())
+
+	info, err := os.Stat(e.cfg.sessionPath())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	opts, err := e.cfg.clientOptions()
+	require.NoError(t, err)
+	bgg := gobgg.NewBGGClient(opts...)
+	assert.Equal(t, "gobgg", bgg.GetActiveUsername())
+	require.Len(t, bgg.GetActiveCookies(), 2)
+	assert.Equal(t, "SessionID", bgg.GetActiveCookies()[1].Name)
+
+	require.NoError(t, dispatch(context.Background(), e, "logout"))
+	s, err := e.cfg.loadSession()
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestReadPasswordPiped(t *testing.T) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+
+	_, err = w.WriteString("piped secret\r\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// A pipe is not a terminal, the password is read as a line
+	e := newTestEnv(t, io.Discard, formatTable, "")
+	e.stdin = r
+	e.in = bufio.NewReader(r)
+	password, err := e.readPassword()
+	require.NoError(t, err)
+	assert.Equal(t, "piped secret", password)
+
+	_, err = e.readPassword()
+	require.Error(t, err)
+}
+
+func TestRateLimiter(t *testing.T) {
+	rl := newRateLimiter(100)
+	first := rl.Take()
+	second := rl.Take()
+	assert.GreaterOrEqual(t, second.Sub(first), 10*time.Millisecond)
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+func hot(cmd *flag.FlagSet) runFunc {
+	var (
+		count int
+		site  string
+		typ   string
+	)
+	cmd.IntVar(&count, "count", 50, "number of items")
+	cmd.StringVar(&site, "site", string(gobgg.GeekSiteBoardGame), "the geek site, boardgame, rpg or videogame")
+	cmd.StringVar(&typ, "type", string(gobgg.HotnessThing), "the object type, thing, person or company")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		items, err := e.bgg.HotItems(ctx, count,
+			gobgg.HotnessGeekSite(gobgg.GeekSite(site)), gobgg.HotnessType(gobgg.HotnessObjectType(typ)))
+		if err != nil {
+			return err
+		}
+
+		return e.out.print(items, []string{"Rank", "ID", "Name", "Year Published", "Delta"}, func(t *table) {
+			for i := range items {
+				t.row(items[i].Rank, items[i].ID, items[i].Name, yearString(items[i].YearPublished),
+					fmt.Sprintf("%+d", items[i].Delta))
+			}
+		})
+	}
+}
+
+func top(cmd *flag.FlagSet) runFunc {
+	var (
+		page      int
+		sort      string
+		subdomain string
+	)
+	cmd.IntVar(&page, "page", 1, "the page number, 100 items per page")
+	cmd.StringVar(&sort, "sort", string(gobgg.RankingsSortRank), "sort by rank, avgrating or numvoters")
+	cmd.StringVar(&subdomain, "subdomain", "", "the subdomain, like strategygames or partygames")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		items, err := e.bgg.Rankings(ctx, gobgg.RankingsPage(page),
+			gobgg.RankingsSortBy(gobgg.RankingsSort(sort)), gobgg.RankingsSubdomain(gobgg.Subdomain(subdomain)))
+		if err != nil {
+			return err
+		}
+
+		header := []string{"Rank", "ID", "Name", "Year Published", "Geek Rating", "Average", "Voters"}
+		return e.out.print(items, header, func(t *table) {
+			for i := range items {
+				t.row(items[i].Rank, items[i].ID, items[i].Name, yearString(items[i].YearPublished),
+					items[i].GeekRating, items[i].AverageRating, items[i].NumVoters)
+			}
+		})
+	}
+}
+
+func printTrends(out *printer, items []gobgg.TrendOutput) error {
+	return out.print(items, []string{"Rank", "ID", "Name", "Delta", "Appearances"}, func(t *table) {
+		for i := range items {
+			t.row(items[i].Rank, items[i].ID, items[i].Name, fmt.Sprintf("%+d", items[i].Delta), items[i].Appearances)
+		}
+	})
+}
+
+func bestSellers(cmd *flag.FlagSet) runFunc {
+	var date string
+	cmd.StringVar(&date, "date", "", "a date in the week (2006-01-02), default is the last week")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		start, err := optionalDate("start", date)
+		if err != nil {
+			return err
+		}
+		if start.IsZero() {
+			start = time.Now().AddDate(0, 0, -7)
+		}
+
+		items, err := e.bgg.BestSellers(ctx, start)
+		if err != nil {
+			return err
+		}
+
+		return printTrends(e.out, items)
+	}
+}
+
+func trends(cmd *flag.FlagSet) runFunc {
+	var (
+		list     string
+		interval string
+		date     string
+	)
+	cmd.StringVar(&list, "list", string(gobgg.TrendListMostPlays), "the list, plays, plays_delta or bestsellers")
+	cmd.StringVar(&interval, "interval", string(gobgg.TrendIntervalWeek), "the interval, week or month")
+	cmd.StringVar(&date, "date", "", "a date in the interval (2006-01-02), default is the last interval")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		start, err := optionalDate("start", date)
+		if err != nil {
+			return err
+		}
+
+		if start.IsZero() {
+			start = time.Now().AddDate(0, 0, -7)
+			if gobgg.TrendInterval(interval) == gobgg.TrendIntervalMonth {
+				start = time.Now().AddDate(0, -1, 0)
+			}
+		}
+
+		var items []gobgg.TrendOutput
+		switch gobgg.TrendList(list) {
+		case gobgg.TrendListMostPlays:
+			items, err = e.bgg.MostPlays(ctx, gobgg.TrendInterval(interval), start)
+		case gobgg.TrendListTrendingPlays:
+			items, err = e.bgg.TrendingPlays(ctx, gobgg.TrendInterval(interval), start)
+		case gobgg.TrendListBestSellers:
+			items, err = e.bgg.BestSellers(ctx, start)
+		default:
+			return fmt.Errorf("%w: invalid list %q", errUsage, list)
+		}
+		if err != nil {
+			return err
+		}
+
+		return printTrends(e.out, items)
+	}
+}
+
+func geekList(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args ...string) error {
+		id, err := requiredID(args)
+		if err != nil {
+			return err
+		}
+
+		items, err := e.bgg.GeekList(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return e.out.print(items, []string{"ID", "Name"}, func(t *table) {
+			for i := range items {
+				t.row(items[i].ID, items[i].Name)
+			}
+		})
+	}
+}
+
+func init() {
+	addCommand("hot", "Get the hotness list", hot)
+	addCommand("top", "Get the rankings", top, withAliases("rankings"))
+	addCommand("bestsellers", "Get the best sellers of a week", bestSellers)
+	addCommand("trends", "Get the plays trends of a week or month", trends)
+	addCommand("geeklist", "Get the items of a geek list", geekList, withArgs("<id>", argNone))
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+func login(cmd *flag.FlagSet) runFunc {
+	var username string
+	cmd.StringVar(&username, "username", "", "the username, it can be the argument too")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		username, err := e.requiredUsername(username, args)
+		if err != nil {
+			return err
+		}
+
+		password := os.Getenv(envPassword)
+		if password == "" {
+			fmt.Fprintf(os.Stderr, "Password for %s: ", username)
+			if password, err = e.readPassword(); err != nil {
+				return err
+			}
+		}
+
+		if err := e.bgg.Login(ctx, username, password); err != nil {
+			return err
+		}
+
+		if err := e.cfg.saveSession(&session{
+			Username: e.bgg.GetActiveUsername(),
+			Cookies:  e.bgg.GetActiveCookies(),
+		}); err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(e.out.w, "Logged in as %s\n", username)
+		return err
+	}
+}
+
+// readPassword reads the password without the echo if the stdin is a terminal, and a line from
+// the input otherwise (like when the password is piped)
+func (e *env) readPassword() (string, error) {
+	if e.stdin != nil && term.IsTerminal(int(e.stdin.Fd())) {
+		b, err := term.ReadPassword(int(e.stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("read password failed: %w", err)
+		}
+		return string(b), nil
+	}
+
+	line, err := e.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read password failed: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func logout(*flag.FlagSet) runFunc {
+	return func(_ context.Context, e *env, args ...string) error {
+		path := e.cfg.sessionPath()
+		if path == "" {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove session failed: %w", err)
+		}
+
+		return nil
+	}
+}
+
+func init() {
+	addCommand("login", "Login and store the session for the commands that need it", login,
+		withArgs("<username>", argUsername))
+	addCommand("logout", "Remove the stored session", logout)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The output formats, any other value is a text/template that is executed for each item
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+	formatYAML  = "yaml"
+)
+
+// table is the tabular form of the command result, it is used for the table and csv formats
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func (t *table) row(fields ...any) {
+	r := make([]string, len(fields))
+	for i := range fields {
+		r[i] = fmt.Sprint(fields[i])
+	}
+	t.rows = append(t.rows, r)
+}
+
+// printer writes the command results in the selected format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	if format == "" {
+		format = formatTable
+	}
+
+	p := &printer{w: w, format: format}
+	switch format {
+	case formatTable, formatJSON, formatJSONL, formatCSV, formatYAML:
+		return p, nil
+	}
+
+	if _, err := template.New("format").Parse(format); err != nil {
+		return nil, fmt.Errorf("%w: invalid format template: %w", errUsage, err)
+	}
+
+	return p, nil
+}
+
+// isTable is true when the command can use its own human readable output
+func (p *printer) isTable() bool {
+	return p.format == formatTable
+}
+
+// print writes the data, the fill function is called only for the table and csv formats
+func (p *printer) print(data any, header []string, fill func(*table)) error {
+	switch p.format {
+	case formatTable, formatCSV:
+		t := &table{header: header}
+		fill(t)
+		if p.format == formatCSV {
+			return p.csv(t)
+		}
+		return p.table(t)
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(data)
+	case formatJSONL:
+		enc := json.NewEncoder(p.w)
+		return eachItem(data, func(item any) error {
+			return enc.Encode(item)
+		})
+	case formatYAML:
+		return p.yaml(data)
+	}
+
+	tpl, err := template.New("format").Parse(p.format)
+	if err != nil {
+		return fmt.Errorf("parse template failed: %w", err)
+	}
+	return eachItem(data, func(item any) error {
+		if err := tpl.Execute(p.w, item); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintln(p.w)
+		return err
+	})
+}
+
+func (p *printer) table(t *table) error {
+	w := tabwriter.NewWriter(p.w, 8, 4, 4, ' ', 0)
+	if len(t.header) > 0 {
+		fmt.Fprintln(w, strings.Join(t.header, "\t"))
+	}
+	for i := range t.rows {
+		fmt.Fprintln(w, strings.Join(t.rows[i], "\t"))
+	}
+
+	return w.Flush()
+}
+
+func (p *printer) csv(t *table) error {
+	w := csv.NewWriter(p.w)
+	if len(t.header) > 0 {
+		if err := w.Write(t.header); err != nil {
+			return err
+		}
+	}
+	if err := w.WriteAll(t.rows); err != nil {
+		return err
+	}
+
+	return w.Error()
+}
+
+// yaml uses the JSON encoding first, so the field names and the order are the same as JSON
+func (p *printer) yaml(data any) error {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("encoding failed: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(js, &node); err != nil {
+		return fmt.Errorf("decoding failed: %w", err)
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return fmt.Errorf("encoding failed: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encoding failed: %w", err)
+	}
+
+	_, err = p.w.Write(buf.Bytes())
+	return err
+}
+
+// blockStyle removes the flow style (the JSON style) from the nodes
+func blockStyle(node *yaml.Node) {
+	node.Style &^= yaml.FlowStyle
+	if node.Kind == yaml.ScalarNode && node.Style&yaml.DoubleQuotedStyle != 0 {
+		// JSON strings are always quoted, let the encoder decide
+		node.Style &^= yaml.DoubleQuotedStyle
+	}
+	for i := range node.Content {
+		blockStyle(node.Content[i])
+	}
+}
+
+// eachItem calls the function for each item if the data is a slice, otherwise for the data itself
+func eachItem(data any, fn func(any) error) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fn(data)
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		if err := fn(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
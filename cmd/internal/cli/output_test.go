@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchFixture = `<?xml version="1.0" encoding="utf-8"?>
+<items total="2" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgame" id="13"><name type="primary" value="CATAN"/><yearpublished value="1995" /></item>
+	<item type="boardgame" id="278"><name type="primary" value="Catan Card Game"/></item>
+</items>`
+
+func runSearch(t *testing.T, format string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, dispatch(context.Background(), newTestEnv(t, &buf, format, ""), "search", "catan"))
+
+	return buf.String()
+}
+
+func TestOutputFormats(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/search",
+		httpmock.NewStringResponder(200, searchFixture))
+
+	assert.Equal(t, `ID      Name               Type         Year Published
+13      CATAN              boardgame    1995
+278     Catan Card Game    boardgame    Not specified
+`, runSearch(t, "table"))
+
+	assert.Equal(t, `ID,Name,Type,Year Published
+13,CATAN,boardgame,1995
+278,Catan Card Game,boardgame,Not specified
+`, runSearch(t, "csv"))
+
+	jsonl := runSearch(t, "jsonl")
+	assert.Equal(t, 2, bytes.Count([]byte(jsonl), []byte("\n")))
+	assert.Contains(t, jsonl, `"Name":"CATAN"`)
+
+	assert.Contains(t, runSearch(t, "json"), "[\n  {\n")
+
+	yml := runSearch(t, "yaml")
+	assert.Contains(t, yml, "- ID: 13\n  Name: CATAN\n")
+	assert.Contains(t, yml, "Name: Catan Card Game\n")
+
+	assert.Equal(t, "13=CATAN\n278=Catan Card Game\n", runSearch(t, "{{.ID}}={{.Name}}"))
+
+	_, err := newPrinter(&bytes.Buffer{}, "{{.ID")
+	require.ErrorIs(t, err, errUsage)
+}
+
+func TestOutputYAMLStrings(t *testing.T) {
+	var buf bytes.Buffer
+	out, err := newPrinter(&buf, formatYAML)
+	require.NoError(t, err)
+	require.NoError(t, out.print(map[string]any{"year": "1995", "name": "Catan", "n": 1}, nil, nil))
+	assert.Equal(t, "n: 1\nname: Catan\nyear: \"1995\"\n", buf.String())
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/export"
+)
+
+func plays(cmd *flag.FlagSet) runFunc {
+	var (
+		username string
+		gameID   int
+		minDate  string
+		maxDate  string
+		exp      string
+	)
+	cmd.StringVar(&username, "username", "", "the username, it can be the argument too")
+	cmd.IntVar(&gameID, "game", 0, "only the plays of this game id")
+	cmd.StringVar(&minDate, "min-date", "", "the plays on or after this date (2006-01-02)")
+	cmd.StringVar(&maxDate, "max-date", "", "the plays on or before this date (2006-01-02)")
+	cmd.StringVar(&exp, "export", "", "export format, csv (play log) or bgstats (BG Stats backup)")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		username, err := e.requiredUsername(username, args)
+		if err != nil {
+			return err
+		}
+
+		opts := []gobgg.PlaysOptionSetter{gobgg.SetUserName(username)}
+		if gameID > 0 {
+			opts = append(opts, gobgg.SetGameID(gameID))
+		}
+		if t, err := optionalDate("min", minDate); err != nil {
+			return err
+		} else if !t.IsZero() {
+			opts = append(opts, gobgg.SetDateRangeMin(t))
+		}
+		if t, err := optionalDate("max", maxDate); err != nil {
+			return err
+		} else if !t.IsZero() {
+			opts = append(opts, gobgg.SetDateRangeMax(t))
+		}
+
+		switch exp {
+		case "", "csv", "bgstats":
+		default:
+			return fmt.Errorf("%w: invalid export %q", errUsage, exp)
+		}
+
+		var all []gobgg.Play
+		for p, err := range e.bgg.PlaysIter(ctx, opts...) {
+			if err != nil {
+				return err
+			}
+			all = append(all, p)
+		}
+
+		switch exp {
+		case "csv":
+			return export.PlaysCSV(e.out.w, all)
+		case "bgstats":
+			return export.PlaysBGStats(e.out.w, username, all)
+		}
+
+		return e.out.print(all, []string{"Date", "Game", "Quantity", "Location", "Players"}, func(t *table) {
+			for i := range all {
+				players := make([]string, 0, len(all[i].Players))
+				for _, p := range all[i].Players {
+					name := p.Name
+					if p.Win {
+						name += "*"
+					}
+					players = append(players, name)
+				}
+				t.row(all[i].Date.Format(dateFormat), all[i].Item.Name, all[i].Quantity, all[i].Location,
+					strings.Join(players, ", "))
+			}
+		})
+	}
+}
+
+func init() {
+	addCommand("plays", "Get the user plays", plays, withArgs("<username>", argUsername))
+}
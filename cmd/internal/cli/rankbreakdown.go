@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+type rankBreakDownResult struct {
+	Votes        gobgg.RankBreakDown `json:"votes"`
+	Total        int64               `json:"total"`
+	Average      float64             `json:"average"`
+	StdDev       float64             `json:"std_dev"`
+	Median       int                 `json:"median"`
+	Mode         int                 `json:"mode"`
+	Polarization float64             `json:"polarization"`
+}
+
+type weightBreakDownResult struct {
+	Votes   gobgg.WeightBreakDown `json:"votes"`
+	Total   int64                 `json:"total"`
+	Average float64               `json:"average"`
+}
+
+// weightBreakDown prints the weight votes, they are from 1 to 5 and have no rating statistics
+func (e *env) weightBreakDown(ctx context.Context, id int64, objectType string) error {
+	wb, err := e.bgg.GetWeightBreakDown(ctx, id,
+		gobgg.RankBreakDownType(gobgg.RankBreakDownObjectType(objectType)))
+	if err != nil {
+		return err
+	}
+
+	result := weightBreakDownResult{Votes: wb, Total: wb.Total(), Average: wb.Average()}
+	return e.out.print(result, []string{"Weight", "Votes"}, func(t *table) {
+		for i := range wb {
+			t.row(i+1, wb[i])
+		}
+		t.row("Total", result.Total)
+		if result.Total > 0 {
+			t.row("Average", fmt.Sprintf("%.2f", result.Average))
+		}
+	})
+}
+
+func rankBreakDown(cmd *flag.FlagSet) runFunc {
+	var (
+		objectType string
+		ratingType string
+	)
+	cmd.StringVar(&objectType, "object-type", string(gobgg.RankBreakDownThing), "the object type, thing or family")
+	cmd.StringVar(&ratingType, "rating-type", string(gobgg.RankBreakDownRating), "the rating type, empty for the user ratings (1 to 10) or weight (1 to 5)")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		id, err := requiredID(args)
+		if err != nil {
+			return err
+		}
+
+		if gobgg.RankBreakDownRatingType(ratingType) == gobgg.RankBreakDownWeight {
+			return e.weightBreakDown(ctx, id, objectType)
+		}
+
+		rb, err := e.bgg.GetRankBreakDown(ctx, id,
+			gobgg.RankBreakDownType(gobgg.RankBreakDownObjectType(objectType)),
+			gobgg.RankBreakDownRatings(gobgg.RankBreakDownRatingType(ratingType)))
+		if err != nil {
+			return err
+		}
+
+		result := rankBreakDownResult{Votes: rb, Total: rb.Total()}
+		if result.Total > 0 {
+			result.Average = rb.Average()
+			result.StdDev = rb.StdDev()
+			result.Median = rb.Median()
+			result.Mode = rb.Mode()
+			result.Polarization = rb.Polarization()
+		}
+
+		return e.out.print(result, []string{"Rating", "Votes"}, func(t *table) {
+			for i := range rb {
+				t.row(i+1, rb[i])
+			}
+			t.row("Total", result.Total)
+			if result.Total > 0 {
+				t.row("Average", fmt.Sprintf("%.2f", result.Average))
+				t.row("StdDev", fmt.Sprintf("%.2f", result.StdDev))
+				t.row("Median", result.Median)
+				t.row("Mode", result.Mode)
+				t.row("Polarization", fmt.Sprintf("%.2f", result.Polarization))
+			}
+		})
+	}
+}
+
+func init() {
+	addCommand("rankbreakdown", "Get the rating break down of a game", rankBreakDown,
+		withAliases("breakdown"), withArgs("<id>", argNone))
+}
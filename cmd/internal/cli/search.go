@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+func search(cmd *flag.FlagSet) runFunc {
+	var exact bool
+	cmd.BoolVar(&exact, "exact", false, "exact search on bgg")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		opts := []gobgg.SearchOptionSetter{}
+		if exact {
+			opts = append(opts, gobgg.SearchExact())
+		}
+		result, err := e.bgg.Search(ctx, strings.Join(args, " "), opts...)
+		if err != nil {
+			return err
+		}
+
+		return e.out.print(result, []string{"ID", "Name", "Type", "Year Published"}, func(t *table) {
+			for _, item := range result {
+				t.row(item.ID, item.Name, item.Type, yearString(item.YearPublished))
+			}
+		})
+	}
+}
+
+func init() {
+	addCommand("search", "Search for a game in boardgae geek", search, withArgs("<query>", argGame))
+}
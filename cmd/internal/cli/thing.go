@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+func thing(cmd *flag.FlagSet) runFunc {
+	var (
+		links       bool
+		names       bool
+		description bool
+	)
+
+	cmd.BoolVar(&links, "links", false, "Show links")
+	cmd.BoolVar(&names, "names", false, "Show alternate names")
+	cmd.BoolVar(&description, "description", true, "Show description")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		id, err := requiredID(args)
+		if err != nil {
+			return err
+		}
+
+		opts := []gobgg.GetOptionSetter{
+			gobgg.GetThingIDs(id),
+		}
+		result, err := e.bgg.GetThings(ctx, opts...)
+		if err != nil {
+			return err
+		}
+
+		if !e.out.isTable() {
+			header := []string{"ID", "Name", "Type", "Year Published", "Min Players", "Max Players", "Play Time", "Rank", "Average"}
+			return e.out.print(result, header, func(t *table) {
+				for _, item := range result {
+					t.row(item.ID, item.Name, item.Type, item.YearPublished, item.MinPlayers, item.MaxPlayers,
+						item.PlayTime, item.RankTotal, item.AverageRate)
+				}
+			})
+		}
+
+		for _, item := range result {
+			year := "Not specified"
+			if item.YearPublished > 0 {
+				year = fmt.Sprint(item.YearPublished)
+			}
+			fmt.Fprintf(e.out.w, "%d\t%s\n\n", item.ID, item.Name)
+			fmt.Fprintf(e.out.w, "%s, Published in %s\n", item.Type, year)
+			fmt.Fprintf(e.out.w, "Play time: %s (Min: %s, Max:%s)\n", item.PlayTime, item.MinPlayTime, item.MaxPlayTime)
+			if item.MinPlayers != item.MaxPlayers {
+				fmt.Fprintf(e.out.w, "Players count: %d-%d\n", item.MinPlayers, item.MaxPlayers)
+			} else {
+				fmt.Fprintf(e.out.w, "Players count: %d\n", item.MinPlayers)
+			}
+			fmt.Fprintln(e.out.w, "Suggested Player count (community votes): ")
+			for i := range item.SuggestedPlayerCount {
+				rec, num, per := item.SuggestedPlayerCount[i].Suggestion()
+				fmt.Fprintf(e.out.w, "%s => %s, %d votes, %0.2f%%\n",
+					item.SuggestedPlayerCount[i].NumPlayers,
+					rec, num, per)
+			}
+			if names && len(item.AlternateNames) > 0 {
+				fmt.Fprintf(e.out.w, "Alternate names: %s\n\n", strings.Join(item.AlternateNames, ", "))
+			}
+			if description {
+				fmt.Fprintln(e.out.w, item.Description)
+			}
+			if links {
+				keys := make([]string, 0, len(item.Links))
+				for i := range item.Links {
+					keys = append(keys, i)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					fmt.Fprintf(e.out.w, "%s: \n", key)
+					for _, lnk := range item.Links[key] {
+						fmt.Fprintf(e.out.w, "\t%d: %s\n", lnk.ID, lnk.Name)
+					}
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func init() {
+	addCommand("thing", "Get a thing from bgg", thing, withAliases("game"), withArgs("<id>", argNone))
+}
@@ -0,0 +1,612 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fzerorubigd/gobgg"
+	"golang.org/x/term"
+)
+
+const (
+	tuiClear      = "\x1b[H\x1b[2J"
+	tuiAltScreen  = "\x1b[?1049h\x1b[?25l"
+	tuiMainScreen = "\x1b[?25h\x1b[?1049l"
+	tuiReverse    = "\x1b[7m"
+	tuiReset      = "\x1b[0m"
+	tuiHelp       = "j/k move  enter open  h back  / search  c collection  p plays  q quit"
+	tuiBarWidth   = 10
+)
+
+// key is a decoded key press
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyOpen
+	keyBack
+	keySearch
+	keyCollection
+	keyPlays
+	keyQuit
+)
+
+// tuiItem is a selectable line, items without open are only for display
+type tuiItem struct {
+	label string
+	open  func(context.Context) (*screen, error)
+}
+
+// screen is one page in the tui, the body is a static text above the items
+type screen struct {
+	title  string
+	body   []string
+	items  []tuiItem
+	cursor int
+	offset int
+}
+
+type tui struct {
+	e      *env
+	w      io.Writer
+	stack  []*screen
+	status string
+
+	height, width int
+}
+
+func newTUI(e *env, height, width int) *tui {
+	t := &tui{
+		e:      e,
+		w:      e.out.w,
+		height: max(height, 8),
+		width:  max(width, 40),
+	}
+	t.stack = []*screen{t.home()}
+
+	return t
+}
+
+func (t *tui) current() *screen {
+	return t.stack[len(t.stack)-1]
+}
+
+func (t *tui) home() *screen {
+	s := &screen{
+		title: "bggcli",
+		body: []string{
+			"Browse BoardGameGeek from the terminal.",
+			"",
+		},
+		items: []tuiItem{
+			{label: "Search games", open: t.searchPrompt},
+			{label: "My collection", open: t.collection},
+			{label: "My plays", open: t.plays},
+		},
+	}
+
+	return s
+}
+
+func (t *tui) readKey() (key, error) {
+	b, err := t.e.in.ReadByte()
+	if err != nil {
+		return keyNone, err
+	}
+
+	switch b {
+	case 'j':
+		return keyDown, nil
+	case 'k':
+		return keyUp, nil
+	case '\r', '\n', 'l':
+		return keyOpen, nil
+	case 'h', 0x7f, 0x08:
+		return keyBack, nil
+	case '/':
+		return keySearch, nil
+	case 'c':
+		return keyCollection, nil
+	case 'p':
+		return keyPlays, nil
+	case 'q', 0x03: // In the raw mode ctrl+c is a key, not a signal
+		return keyQuit, nil
+	case 0x1b:
+		// A lone escape is back, the arrow keys are ESC [ A..D and they come in one read
+		if t.e.in.Buffered() < 2 {
+			return keyBack, nil
+		}
+		seq := make([]byte, 2)
+		if _, err := io.ReadFull(t.e.in, seq); err != nil {
+			return keyNone, err
+		}
+		if seq[0] != '[' {
+			return keyNone, nil
+		}
+		switch seq[1] {
+		case 'A':
+			return keyUp, nil
+		case 'B':
+			return keyDown, nil
+		case 'C':
+			return keyOpen, nil
+		case 'D':
+			return keyBack, nil
+		}
+	}
+
+	return keyNone, nil
+}
+
+// readLine reads the search query, the terminal is in the raw mode so it echoes the input itself
+func (t *tui) readLine(prompt string) (string, error) {
+	fmt.Fprintf(t.w, "\r\x1b[K%s", prompt)
+	var line []byte
+	for {
+		b, err := t.e.in.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		switch b {
+		case '\r', '\n':
+			return strings.TrimSpace(string(line)), nil
+		case 0x1b:
+			return "", nil
+		case 0x7f, 0x08:
+			// Remove the last rune, not the last byte of a multi-byte character
+			if len(line) > 0 {
+				_, size := utf8.DecodeLastRune(line)
+				line = line[:len(line)-size]
+				fmt.Fprint(t.w, "\b \b")
+			}
+		default:
+			line = append(line, b)
+			_, _ = t.w.Write([]byte{b})
+		}
+	}
+}
+
+func (t *tui) lines(s *screen) []string {
+	result := make([]string, 0, len(s.body)+len(s.items))
+	result = append(result, s.body...)
+	for i := range s.items {
+		prefix := "  "
+		if i == s.cursor {
+			prefix = "> "
+		}
+		result = append(result, prefix+s.items[i].label)
+	}
+
+	return result
+}
+
+func (t *tui) render() {
+	s := t.current()
+	view := t.height - 3
+	all := t.lines(s)
+
+	// Keep the cursor in the view, scroll as little as possible
+	if len(s.items) > 0 {
+		line := len(s.body) + s.cursor
+		if line < s.offset {
+			s.offset = line
+		}
+		if line >= s.offset+view {
+			s.offset = line - view + 1
+		}
+	}
+	s.offset = max(min(s.offset, len(all)-view), 0)
+
+	titles := make([]string, len(t.stack))
+	for i := range t.stack {
+		titles[i] = t.stack[i].title
+	}
+
+	var b strings.Builder
+	b.WriteString(tuiClear)
+	b.WriteString(tuiReverse + truncate(strings.Join(titles, " > "), t.width) + tuiReset + "\r\n")
+	for i := s.offset; i < len(all) && i < s.offset+view; i++ {
+		line := truncate(all[i], t.width)
+		if len(s.items) > 0 && i == len(s.body)+s.cursor {
+			line = tuiReverse + line + tuiReset
+		}
+		b.WriteString(line + "\r\n")
+	}
+	b.WriteString(truncate(t.status, t.width) + "\r\n")
+	b.WriteString(truncate(tuiHelp, t.width))
+
+	fmt.Fprint(t.w, b.String())
+}
+
+// push loads the next screen, the errors are shown in the status line
+func (t *tui) push(ctx context.Context, open func(context.Context) (*screen, error)) {
+	t.status = "Loading..."
+	t.render()
+
+	s, err := open(ctx)
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+
+	t.status = ""
+	// A nil screen means the action is canceled
+	if s != nil {
+		t.stack = append(t.stack, s)
+	}
+}
+
+func (t *tui) searchPrompt(ctx context.Context) (*screen, error) {
+	query, err := t.readLine("Search: ")
+	if err != nil || query == "" {
+		return nil, err
+	}
+
+	return t.searchResult(ctx, query)
+}
+
+// run is the main loop, it returns on quit or when the input is closed
+func (t *tui) run(ctx context.Context) error {
+	for {
+		t.render()
+		k, err := t.readKey()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		s := t.current()
+		switch k {
+		case keyQuit:
+			return nil
+		case keyUp:
+			if len(s.items) == 0 {
+				s.offset--
+			} else if s.cursor > 0 {
+				s.cursor--
+			}
+		case keyDown:
+			if len(s.items) == 0 {
+				s.offset++
+			} else if s.cursor < len(s.items)-1 {
+				s.cursor++
+			}
+		case keyOpen:
+			if len(s.items) == 0 {
+				continue
+			}
+			if s.items[s.cursor].open == nil {
+				t.status = "Nothing to open"
+				continue
+			}
+			t.push(ctx, s.items[s.cursor].open)
+		case keyBack:
+			t.status = ""
+			if len(t.stack) > 1 {
+				t.stack = t.stack[:len(t.stack)-1]
+			}
+		case keySearch:
+			t.push(ctx, t.searchPrompt)
+		case keyCollection:
+			t.push(ctx, t.collection)
+		case keyPlays:
+			t.push(ctx, t.plays)
+		}
+	}
+}
+
+func (t *tui) thingItem(label string, id int64) tuiItem {
+	return tuiItem{
+		label: label,
+		open: func(ctx context.Context) (*screen, error) {
+			return t.thing(ctx, id)
+		},
+	}
+}
+
+func (t *tui) searchResult(ctx context.Context, query string) (*screen, error) {
+	result, err := t.e.bgg.Search(ctx, query, gobgg.SearchTypes(gobgg.BoardGameType, gobgg.BoardGameExpansionType))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &screen{title: fmt.Sprintf("Search %q", query)}
+	seen := make(map[int64]bool)
+	for i := range result {
+		if seen[result[i].ID] {
+			continue
+		}
+		seen[result[i].ID] = true
+		s.items = append(s.items, t.thingItem(
+			fmt.Sprintf("%s (%s)", result[i].Name, yearString(result[i].YearPublished)), result[i].ID))
+	}
+	if len(s.items) == 0 {
+		s.body = []string{"No result"}
+	}
+
+	return s, nil
+}
+
+// bar is a text progress bar for the percent
+func bar(percent float32) string {
+	n := int(percent*tuiBarWidth/100 + 0.5)
+	n = max(min(n, tuiBarWidth), 0)
+
+	return strings.Repeat("#", n) + strings.Repeat(".", tuiBarWidth-n)
+}
+
+func (t *tui) thing(ctx context.Context, id int64) (*screen, error) {
+	result, err := t.e.bgg.GetThings(ctx, gobgg.GetThingIDs(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("thing %d not found", id)
+	}
+
+	item := result[0]
+	s := &screen{title: item.Name}
+	players := fmt.Sprint(item.MinPlayers)
+	if item.MinPlayers != item.MaxPlayers {
+		players = fmt.Sprintf("%d-%d", item.MinPlayers, item.MaxPlayers)
+	}
+	rank := "Not Ranked"
+	if item.RankTotal > 0 {
+		rank = fmt.Sprint(item.RankTotal)
+	}
+	s.body = append(s.body,
+		fmt.Sprintf("%s (%s), %s", item.Name, yearString(item.YearPublished), item.Type),
+		fmt.Sprintf("Players: %s  Play time: %s min  Age: %s+", players, item.PlayTime, item.MinAge),
+		fmt.Sprintf("Rank: %s  Geek rating: %.2f  Average: %.2f (%d votes)  Weight: %.2f",
+			rank, item.BayesAverage, item.AverageRate, item.UsersRated, item.AverageWeight),
+		"",
+	)
+
+	if len(item.SuggestedPlayerCount) > 0 {
+		s.body = append(s.body, "Suggested player count:")
+		for i := range item.SuggestedPlayerCount {
+			sp := &item.SuggestedPlayerCount[i]
+			s.body = append(s.body, fmt.Sprintf("%5s  best %s %3.0f%%  rec %s %3.0f%%  not %s %3.0f%%",
+				sp.NumPlayers,
+				bar(sp.BestPercentile()), sp.BestPercentile(),
+				bar(sp.RecommendedPercentile()), sp.RecommendedPercentile(),
+				bar(sp.NotRecommendedPercentile()), sp.NotRecommendedPercentile()))
+		}
+		s.body = append(s.body, "")
+	}
+
+	description := wrap(item.Description, t.width)
+	if len(description) > 5 {
+		description = append(description[:5], "...")
+	}
+	s.body = append(s.body, description...)
+	s.body = append(s.body, "")
+
+	s.items = append(s.items,
+		tuiItem{label: "Description", open: func(context.Context) (*screen, error) {
+			return &screen{title: "Description", body: wrap(item.Description, t.width)}, nil
+		}},
+		tuiItem{label: "Plays", open: func(ctx context.Context) (*screen, error) {
+			return t.playList(ctx, "Plays", gobgg.SetGameID(int(id)))
+		}},
+	)
+
+	categories := make([]string, 0, len(item.Links))
+	for cat := range item.Links {
+		categories = append(categories, cat)
+	}
+	sort.Strings(categories)
+	for _, cat := range categories {
+		name := strings.TrimPrefix(cat, "boardgame")
+		for _, lnk := range item.Links[cat] {
+			s.items = append(s.items, t.linkItem(cat, name+": "+lnk.Name, lnk))
+		}
+	}
+
+	return s, nil
+}
+
+// linkItem opens the designers, artists and families, the other links are only for display
+func (t *tui) linkItem(category, label string, lnk gobgg.Link) tuiItem {
+	switch category {
+	case gobgg.BoardGameDesigner, gobgg.BoardGameArtist:
+		return tuiItem{label: label, open: func(ctx context.Context) (*screen, error) {
+			return t.person(ctx, category, lnk)
+		}}
+	case gobgg.BoardGameFamily:
+		return tuiItem{label: label, open: func(ctx context.Context) (*screen, error) {
+			return t.family(ctx, lnk)
+		}}
+	}
+
+	return tuiItem{label: label}
+}
+
+// person shows the designer or artist with the link to the page, the image is optional and a
+// failure to load it is shown in the body
+func (t *tui) person(ctx context.Context, category string, lnk gobgg.Link) (*screen, error) {
+	s := &screen{
+		title: lnk.Name,
+		body: []string{
+			fmt.Sprintf("%s (%s)", lnk.Name, strings.TrimPrefix(category, "boardgame")),
+			fmt.Sprintf("https://boardgamegeek.com/%s/%d", category, lnk.ID),
+			"",
+		},
+	}
+
+	img, err := t.e.bgg.PersonImage(ctx, lnk.ID)
+	switch {
+	case err != nil:
+		s.body = append(s.body, "Image: "+err.Error())
+	case img.Image != "":
+		s.body = append(s.body, "Image: "+img.Image, "Thumbnail: "+img.Thumbnail)
+	}
+
+	return s, nil
+}
+
+// family lists the games in the family, the xml api has no family games so it is based on the plays
+func (t *tui) family(ctx context.Context, lnk gobgg.Link) (*screen, error) {
+	plays, err := t.e.bgg.Plays(ctx, gobgg.SetFamilyID(int(lnk.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &screen{
+		title: lnk.Name,
+		body:  []string{"Games from the recent plays in this family:", ""},
+	}
+	seen := make(map[int64]bool)
+	for i := range plays.Items {
+		game := plays.Items[i].Item
+		if seen[game.ID] {
+			continue
+		}
+		seen[game.ID] = true
+		s.items = append(s.items, t.thingItem(game.Name, game.ID))
+	}
+
+	return s, nil
+}
+
+func (t *tui) collection(ctx context.Context) (*screen, error) {
+	username, err := t.e.requiredUsername("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := t.e.bgg.GetCollection(ctx, username, gobgg.SetCollectionTypes(gobgg.CollectionTypeOwn))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &screen{title: username + "'s collection"}
+	for i := range items {
+		s.items = append(s.items, t.thingItem(
+			fmt.Sprintf("%s (%s)", items[i].Name, yearString(items[i].YearPublished)), items[i].ID))
+	}
+	if len(s.items) == 0 {
+		s.body = []string{"The collection is empty"}
+	}
+
+	return s, nil
+}
+
+func (t *tui) plays(ctx context.Context) (*screen, error) {
+	username, err := t.e.requiredUsername("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.playList(ctx, username+"'s plays", gobgg.SetUserName(username))
+}
+
+func (t *tui) playList(ctx context.Context, title string, setters ...gobgg.PlaysOptionSetter) (*screen, error) {
+	plays, err := t.e.bgg.Plays(ctx, setters...)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &screen{title: title}
+	for i := range plays.Items {
+		p := &plays.Items[i]
+		names := make([]string, 0, len(p.Players))
+		for j := range p.Players {
+			names = append(names, p.Players[j].Name)
+		}
+		s.items = append(s.items, t.thingItem(fmt.Sprintf("%s  %s x%g  %s",
+			p.Date.Format(dateFormat), p.Item.Name, p.Quantity, strings.Join(names, ", ")), p.Item.ID))
+	}
+	if len(s.items) == 0 {
+		s.body = []string{"No plays"}
+	}
+
+	return s, nil
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+
+	return string(r[:width-1]) + "~"
+}
+
+// wrap splits the text into the lines no longer than the width
+func wrap(text string, width int) []string {
+	var result []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && len([]rune(line))+1+len([]rune(word)) > width {
+				result = append(result, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		result = append(result, line)
+	}
+
+	return result
+}
+
+// rawMode turns off the line buffering and the echo, the returned function restores the terminal
+func rawMode(fd int) (func(), error) {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("raw mode failed: %w", err)
+	}
+
+	return func() { _ = term.Restore(fd, state) }, nil
+}
+
+func terminalSize(fd int) (int, int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		return 24, 80
+	}
+
+	return height, width
+}
+
+func tuiCmd(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args ...string) error {
+		if e.stdin == nil || !term.IsTerminal(int(e.stdin.Fd())) {
+			return fmt.Errorf("%w: the tui needs a terminal", errUsage)
+		}
+
+		fd := int(e.stdin.Fd())
+		restore, err := rawMode(fd)
+		if err != nil {
+			return err
+		}
+		defer restore()
+
+		fmt.Fprint(e.out.w, tuiAltScreen)
+		defer fmt.Fprint(e.out.w, tuiMainScreen)
+
+		height, width := terminalSize(fd)
+		return newTUI(e, height, width).run(ctx)
+	}
+}
+
+func init() {
+	addCommand("tui", "Browse the games, collection and plays in a terminal UI", tuiCmd)
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const thingFixture = `<?xml version="1.0" encoding="utf-8"?>
+<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgame" id="13">
+		<name type="primary" sortindex="1" value="CATAN" />
+		<description>Trade, build and settle.</description>
+		<yearpublished value="1995" />
+		<minplayers value="3" />
+		<maxplayers value="4" />
+		<poll name="suggested_numplayers" title="User Suggested Number of Players" totalvotes="10">
+			<results numplayers="4">
+				<result value="Best" numvotes="8" />
+				<result value="Recommended" numvotes="2" />
+				<result value="Not Recommended" numvotes="0" />
+			</results>
+		</poll>
+		<playingtime value="120" />
+		<minage value="10" />
+		<link type="boardgamedesigner" id="11" value="Klaus Teuber" />
+		<link type="boardgamemechanic" id="2072" value="Dice Rolling" />
+	</item>
+</items>`
+
+const personFixture = `<?xml version="1.0" encoding="utf-8"?>
+<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgamedesigner" id="11">
+		<thumbnail>https://cf.geekdo-images.com/thumb.jpg</thumbnail>
+		<image>https://cf.geekdo-images.com/image.jpg</image>
+	</item>
+</items>`
+
+func TestTUI(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/search",
+		httpmock.NewStringResponder(200, searchFixture))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		httpmock.NewStringResponder(200, thingFixture))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/person",
+		httpmock.NewStringResponder(200, personFixture))
+
+	var buf bytes.Buffer
+	// search, open the first result, move to the designer and open it, then back and open the mechanic
+	e := newTestEnv(t, &buf, formatTable, "/catan\n\njj\nhj\x1b[B\n")
+	ui := newTUI(e, 30, 100)
+	require.NoError(t, ui.run(context.Background()))
+
+	out := buf.String()
+	assert.Contains(t, out, "bggcli > Search \"catan\" > CATAN")
+	assert.Contains(t, out, "4  best ########.. ")
+	assert.Contains(t, out, "designer: Klaus Teuber")
+	assert.Contains(t, out, "Klaus Teuber (designer)")
+	assert.Contains(t, out, "https://boardgamegeek.com/boardgamedesigner/11")
+	assert.Contains(t, out, "Image: https://cf.geekdo-images.com/image.jpg")
+	assert.Contains(t, out, "Nothing to open")
+	require.Len(t, ui.stack, 3)
+	assert.Equal(t, "CATAN", ui.current().title)
+
+	// there is no username, the error is shown in the status line
+	buf.Reset()
+	ui = newTUI(newTestEnv(t, &buf, formatTable, "cq"), 30, 100)
+	require.NoError(t, ui.run(context.Background()))
+	assert.Contains(t, buf.String(), "invalid usage")
+	require.Len(t, ui.stack, 1)
+}
+
+func TestTUIPerson(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		httpmock.NewStringResponder(200, thingFixture))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/person",
+		httpmock.NewStringResponder(500, "server error"))
+
+	// The image is not loaded, the name and the link are still shown
+	var buf bytes.Buffer
+	ui := newTUI(newTestEnv(t, &buf, formatTable, ""), 30, 100)
+	s, err := ui.thing(context.Background(), 13)
+	require.NoError(t, err)
+	s, err = s.items[2].open(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Klaus Teuber", s.title)
+	assert.Equal(t, "Klaus Teuber (designer)", s.body[0])
+	assert.Equal(t, "https://boardgamegeek.com/boardgamedesigner/11", s.body[1])
+	assert.Contains(t, s.body[3], "Image: ")
+}
+
+func TestTUIReadLine(t *testing.T) {
+	var buf bytes.Buffer
+	// The backspace removes the whole multi-byte rune
+	ui := newTUI(newTestEnv(t, &buf, formatTable, "cata\u00f1\x7fn\r"), 30, 100)
+	line, err := ui.readLine("Search: ")
+	require.NoError(t, err)
+	assert.Equal(t, "catan", line)
+	assert.Contains(t, buf.String(), "cata\u00f1\b \bn")
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"aaa bb", "cccc", "", "d"}, wrap("aaa bb cccc\n\nd", 7))
+	assert.Equal(t, "abcd~", truncate("abcdefgh", 5))
+	assert.Equal(t, "..........", bar(0))
+	assert.Equal(t, "##########", bar(100))
+}
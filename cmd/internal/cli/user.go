@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"flag"
+)
+
+func user(cmd *flag.FlagSet) runFunc {
+	var username string
+	cmd.StringVar(&username, "username", "", "the username, it can be the argument too")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		username, err := e.requiredUsername(username, args)
+		if err != nil {
+			return err
+		}
+
+		u, err := e.bgg.GetUser(ctx, username)
+		if err != nil {
+			return err
+		}
+
+		return e.out.print(u, nil, func(t *table) {
+			t.row("ID:", u.UserID)
+			t.row("Username:", u.UserName)
+			t.row("Name:", u.FirstName+" "+u.LastName)
+			t.row("Registered:", yearString(u.Year))
+			t.row("Avatar:", u.AvatarLink)
+		})
+	}
+}
+
+func person(*flag.FlagSet) runFunc {
+	return func(ctx context.Context, e *env, args ...string) error {
+		id, err := requiredID(args)
+		if err != nil {
+			return err
+		}
+
+		p, err := e.bgg.PersonImage(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return e.out.print(p, nil, func(t *table) {
+			t.row("ID:", p.ID)
+			t.row("Thumbnail:", p.Thumbnail)
+			t.row("Image:", p.Image)
+		})
+	}
+}
+
+func init() {
+	addCommand("user", "Get the user profile", user, withArgs("<username>", argUsername))
+	addCommand("person", "Get the person (designer, artist...) images", person, withArgs("<id>", argNone))
+}
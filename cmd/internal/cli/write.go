@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// errAborted is returned when the user does not confirm the action
+var errAborted = errors.New("aborted")
+
+// parsePlayer parses the name:score:win player spec, the score and win are optional
+func parsePlayer(spec string) (gobgg.Player, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 || strings.TrimSpace(parts[0]) == "" {
+		return gobgg.Player{}, fmt.Errorf("%w: invalid player %q, the format is name:score:win", errUsage, spec)
+	}
+
+	p := gobgg.Player{Name: strings.TrimSpace(parts[0]), NoScore: true}
+	if len(parts) > 1 {
+		p.ScoreText = strings.TrimSpace(parts[1])
+		p.Score, _ = strconv.ParseFloat(p.ScoreText, 64)
+		p.NoScore = p.ScoreText == ""
+	}
+	if len(parts) > 2 {
+		switch strings.ToLower(strings.TrimSpace(parts[2])) {
+		case "win", "1", "true", "yes":
+			p.Win = true
+		case "", "0", "false", "no":
+		default:
+			return gobgg.Player{}, fmt.Errorf("%w: invalid win value in player %q", errUsage, spec)
+		}
+	}
+
+	return p, nil
+}
+
+// resolveGame returns the game id, the game is an id or an exact name
+func (e *env) resolveGame(ctx context.Context, game string) (int64, string, error) {
+	if id, err := strconv.ParseInt(game, 10, 64); err == nil {
+		return id, game, nil
+	}
+
+	result, err := e.bgg.Search(ctx, game, gobgg.SearchExact(),
+		gobgg.SearchTypes(gobgg.BoardGameType, gobgg.BoardGameExpansionType))
+	if err != nil {
+		return 0, "", fmt.Errorf("search %q failed: %w", game, err)
+	}
+
+	var candidates []string
+	seen := make(map[int64]bool)
+	for i := range result {
+		if seen[result[i].ID] {
+			continue
+		}
+		seen[result[i].ID] = true
+		candidates = append(candidates, fmt.Sprintf("%d (%s, %s)", result[i].ID, result[i].Name, yearString(result[i].YearPublished)))
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, "", fmt.Errorf("game %q not found", game)
+	case 1:
+		return result[0].ID, result[0].Name, nil
+	}
+
+	return 0, "", fmt.Errorf("%w: %q matches more than one game, use the id: %s",
+		errUsage, game, strings.Join(candidates, ", "))
+}
+
+// confirm asks the user, anything other than y or yes is a no
+func (e *env) confirm(question string) error {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	line, err := e.in.ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("read confirmation failed: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+
+	return errAborted
+}
+
+type playerList []gobgg.Player
+
+func (pl *playerList) String() string {
+	return fmt.Sprint(len(*pl), " players")
+}
+
+func (pl *playerList) Set(spec string) error {
+	p, err := parsePlayer(spec)
+	if err != nil {
+		return err
+	}
+	*pl = append(*pl, p)
+
+	return nil
+}
+
+func logPlay(cmd *flag.FlagSet) runFunc {
+	var (
+		game     string
+		players  playerList
+		duration time.Duration
+		location string
+		date     string
+		comment  string
+		yes      bool
+		dryRun   bool
+	)
+	cmd.StringVar(&game, "game", "", "the game id or the exact name, it can be the argument too")
+	cmd.Var(&players, "player", "a player as name:score:win, can be repeated")
+	cmd.DurationVar(&duration, "duration", 0, "the play duration, like 90m")
+	cmd.StringVar(&location, "location", "", "the location")
+	cmd.StringVar(&date, "date", "", "the play date (2006-01-02), default is today")
+	cmd.StringVar(&comment, "comment", "", "the comment")
+	cmd.BoolVar(&yes, "yes", false, "do not ask for the confirmation")
+	cmd.BoolVar(&dryRun, "dry-run", false, "print the payload instead of posting it")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		if game == "" {
+			game = strings.Join(args, " ")
+		}
+		if game == "" {
+			return fmt.Errorf("%w: the game is required", errUsage)
+		}
+
+		playDate, err := optionalDate("play", date)
+		if err != nil {
+			return err
+		}
+		if playDate.IsZero() {
+			playDate = time.Now()
+		}
+
+		id, name, err := e.resolveGame(ctx, game)
+		if err != nil {
+			return err
+		}
+
+		play := gobgg.Play{
+			Date:     playDate,
+			Quantity: 1,
+			Length:   duration,
+			Location: location,
+			Comment:  comment,
+			Item: gobgg.Item{
+				ID:   id,
+				Name: name,
+				Type: "thing",
+			},
+			Players: players,
+		}
+
+		if dryRun {
+			payload, err := gobgg.PlayPayload(&play)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(e.out.w, string(payload))
+			return err
+		}
+
+		if !yes {
+			question := fmt.Sprintf("Log a play of %s (%d) on %s with %d players?",
+				name, id, playDate.Format(dateFormat), len(players))
+			if err := e.confirm(question); err != nil {
+				return err
+			}
+		}
+
+		total, err := e.bgg.PostPlay(ctx, &play)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(e.out.w, "Play %d is logged, %d plays of this game\n", play.ID, total)
+		return err
+	}
+}
+
+func rate(cmd *flag.FlagSet) runFunc {
+	var dryRun bool
+	cmd.BoolVar(&dryRun, "dry-run", false, "print the payload instead of sending it")
+
+	return func(ctx context.Context, e *env, args ...string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("%w: the game id and the rating are required", errUsage)
+		}
+
+		id, err := requiredID(args[:1])
+		if err != nil {
+			return err
+		}
+
+		rating, err := strconv.ParseFloat(args[1], 64)
+		if err != nil || rating <= 0 || rating > 10 {
+			return fmt.Errorf("%w: the rating should be more than 0 and at most 10, but is %q", errUsage, args[1])
+		}
+
+		if dryRun {
+			// The payload is the collection item with the new rating, reading it needs the login
+			payload, err := e.bgg.RankPayload(ctx, id, rating)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(e.out.w, string(payload))
+			return err
+		}
+
+		return e.bgg.SetRank(ctx, id, rating)
+	}
+}
+
+func init() {
+	addCommand("log-play", "Log a play, the user should be logged in", logPlay,
+		withArgs("<game id or name>", argGame))
+	addCommand("rate", "Rate a game, the user should be logged in", rate, withArgs("<id> <rating>", argNone))
+}
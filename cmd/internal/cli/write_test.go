@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlayer(t *testing.T) {
+	p, err := parsePlayer("Forud:42:win")
+	require.NoError(t, err)
+	assert.Equal(t, gobgg.Player{Name: "Forud", ScoreText: "42", Score: 42, Win: true}, p)
+
+	p, err = parsePlayer("GoBGG")
+	require.NoError(t, err)
+	assert.Equal(t, gobgg.Player{Name: "GoBGG", NoScore: true}, p)
+
+	for _, spec := range []string{"", ":12", "a:1:maybe", "a:1:1:1"} {
+		_, err = parsePlayer(spec)
+		require.ErrorIs(t, err, errUsage, spec)
+	}
+}
+
+func TestLogPlay(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/search",
+		httpmock.NewStringResponder(200, searchFixture))
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	e := newTestEnv(t, &buf, formatTable, "")
+	require.NoError(t, dispatch(ctx, e, "log-play", "-dry-run", "-date", "2024-01-02",
+		"-player", "Forud:10:win", "-player", "GoBGG:8", "-duration", "20m", "23383"))
+	assert.Contains(t, buf.String(), `"objectid":"23383"`)
+	assert.Contains(t, buf.String(), `"playdate":"2024-01-02"`)
+	assert.Contains(t, buf.String(), `"name":"Forud"`)
+
+	err := dispatch(ctx, e, "log-play", "-dry-run", "catan")
+	require.ErrorIs(t, err, errUsage)
+	assert.Contains(t, err.Error(), "278")
+
+	e = newTestEnv(t, io.Discard, formatTable, "n\n")
+	e.bgg = gobgg.NewBGGClient(gobgg.SetCookies("gobgg", []*http.Cookie{{Name: "SessionID", Value: "abcd"}}))
+	require.ErrorIs(t, dispatch(ctx, e, "log-play", "23383"), errAborted)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	require.ErrorIs(t, dispatch(ctx, e, "log-play"), errUsage)
+}
+
+func TestRate(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/user",
+		httpmock.NewStringResponder(200, `<user id="1000001" name="gobgg"><yearregistered value="2015"/></user>`))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/api/collections",
+		httpmock.NewStringResponder(200, `{"items":[{"collid":"42","objectid":"23383","own":"1"}]}`))
+	httpmock.RegisterResponder("PUT", "https://boardgamegeek.com/api/collectionitems/42",
+		httpmock.NewStringResponder(200, `{}`))
+
+	ctx := context.Background()
+	var buf bytes.Buffer
+	e := newTestEnv(t, &buf, formatTable, "")
+	// The dry run reads the collection item, so it needs the login too
+	require.Error(t, dispatch(ctx, e, "rate", "-dry-run", "23383", "7.5"))
+
+	e.bgg = gobgg.NewBGGClient(gobgg.SetCookies("gobgg", []*http.Cookie{{Name: "SessionID", Value: "abcd"}}))
+	require.NoError(t, dispatch(ctx, e, "rate", "-dry-run", "23383", "7.5"))
+	assert.JSONEq(t, `{"item":{"collid":"42","objectid":"23383","own":"1","rating":7.5}}`, buf.String())
+	assert.Zero(t, httpmock.GetCallCountInfo()["PUT https://boardgamegeek.com/api/collectionitems/42"])
+
+	require.ErrorIs(t, dispatch(ctx, e, "rate", "23383", "11"), errUsage)
+	require.ErrorIs(t, dispatch(ctx, e, "rate", "23383", "0"), errUsage)
+	require.ErrorIs(t, dispatch(ctx, e, "rate", "23383"), errUsage)
+
+	require.NoError(t, dispatch(ctx, e, "rate", "23383", "8"))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["PUT https://boardgamegeek.com/api/collectionitems/42"])
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const playsFixture = `<?xml version="1.0" encoding="utf-8"?>
+<plays username="gobgg" userid="3597059" total="1" page="1" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<play id="81234" date="2024-01-02" quantity="2" length="45" incomplete="0" nowinstats="1" location="Home">
+		<item name="Ark Nova" objecttype="thing" objectid="342942" />
+		<players>
+			<player username="gobgg" userid="3597059" name="GoBGG" startposition="1" color="Red" score="12.5" new="1" rating="7.5" win="1" />
+		</players>
+	</play>
+</plays>`
+
+// capture runs the entry point without the user config and returns the exit code and the output
+func capture(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("BGGCLI_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("XDG_CACHE_HOME", dir)
+	for _, env := range []string{"BGG_TOKEN", "BGG_USERNAME", "BGG_PROXY", "BGG_TIMEOUT", "BGG_PASSWORD"} {
+		t.Setenv(env, "")
+	}
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	saved := os.Stdout
+	os.Stdout = w
+	code := run(args)
+	os.Stdout = saved
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	return code, string(out)
+}
+
+func TestRun(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/plays",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "gobgg", req.URL.Query().Get("username"))
+			return httpmock.NewStringResponse(200, playsFixture), nil
+		})
+
+	// The global flags are accepted along with the command flags
+	code, out := capture(t, "-min-date", "2024-01-01", "-format", "json", "gobgg")
+	require.Equal(t, 0, code)
+	var plays []map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &plays))
+	require.Len(t, plays, 1)
+	assert.Equal(t, "Ark Nova", plays[0]["item"].(map[string]any)["name"])
+
+	code, _ = capture(t, "-format", "{{", "gobgg")
+	assert.Equal(t, 2, code)
+}
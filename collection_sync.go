@@ -0,0 +1,218 @@
+package gobgg
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// CollectionEventType is the type of the change in a collection sync
+type CollectionEventType string
+
+const (
+	// CollectionItemAdded is a new item in the collection
+	CollectionItemAdded CollectionEventType = "added"
+	// CollectionItemRemoved is an item that is not in the collection anymore
+	CollectionItemRemoved CollectionEventType = "removed"
+	// CollectionItemChanged is an item that has a change in status, rating or plays
+	CollectionItemChanged CollectionEventType = "changed"
+)
+
+const (
+	// CollectionFieldStatus is the collection status field
+	CollectionFieldStatus = "status"
+	// CollectionFieldRating is the user rating field
+	CollectionFieldRating = "rating"
+	// CollectionFieldPlays is the number of plays field
+	CollectionFieldPlays = "numplays"
+)
+
+// CollectionFieldChange is a single field change in an item
+type CollectionFieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// CollectionEvent is a single change in the collection
+type CollectionEvent struct {
+	Type    CollectionEventType     `json:"type"`
+	Item    CollectionItem          `json:"item"`
+	Changes []CollectionFieldChange `json:"changes,omitempty"`
+}
+
+// CollectionSnapshot is the state of a user collection at some point, it is
+// safe to store it (for example as JSON) and use it for the next sync
+type CollectionSnapshot struct {
+	Username string `json:"username"`
+	// Items are keyed by the collection id, since a user can have multiple copies of one game
+	Items map[int64]CollectionItem `json:"items"`
+	// FetchedAt is the time of the last sync (full or incremental)
+	FetchedAt time.Time `json:"fetched_at"`
+	// FullFetchedAt is the time of the last full sync
+	FullFetchedAt time.Time `json:"full_fetched_at"`
+}
+
+// CollectionSyncOption is the option for the collection sync
+type CollectionSyncOption struct {
+	fullInterval time.Duration
+	options      []CollectionOptionSetter
+	now          func() time.Time
+}
+
+// CollectionSyncOptionSetter is the option setter for the collection sync
+type CollectionSyncOptionSetter func(*CollectionSyncOption)
+
+// SyncFullInterval sets the interval between full fetches, removals are only detected on a
+// full fetch. Default is one day, zero means always do a full fetch
+func SyncFullInterval(d time.Duration) CollectionSyncOptionSetter {
+	return func(opt *CollectionSyncOption) {
+		opt.fullInterval = d
+	}
+}
+
+// SyncCollectionOptions sets extra options passed to every GetCollection call. Do not use
+// SetModifiedSince here, the syncer handles it
+func SyncCollectionOptions(options ...CollectionOptionSetter) CollectionSyncOptionSetter {
+	return func(opt *CollectionSyncOption) {
+		opt.options = options
+	}
+}
+
+func collectionKey(item *CollectionItem) int64 {
+	if item.CollID > 0 {
+		return item.CollID
+	}
+
+	// Should not happen, but it's better than losing the item
+	return -item.ID
+}
+
+func diffCollectionItem(old, current *CollectionItem) []CollectionFieldChange {
+	var changes []CollectionFieldChange
+	oldStatus, newStatus := slices.Clone(old.CollectionStatus), slices.Clone(current.CollectionStatus)
+	slices.Sort(oldStatus)
+	slices.Sort(newStatus)
+	if !slices.Equal(oldStatus, newStatus) {
+		changes = append(changes, CollectionFieldChange{
+			Field: CollectionFieldStatus,
+			Old:   old.CollectionStatus,
+			New:   current.CollectionStatus,
+		})
+	}
+
+	if old.Rating != current.Rating {
+		changes = append(changes, CollectionFieldChange{
+			Field: CollectionFieldRating,
+			Old:   old.Rating,
+			New:   current.Rating,
+		})
+	}
+
+	if old.NumPlays != current.NumPlays {
+		changes = append(changes, CollectionFieldChange{
+			Field: CollectionFieldPlays,
+			Old:   old.NumPlays,
+			New:   current.NumPlays,
+		})
+	}
+
+	return changes
+}
+
+// SyncCollection fetches the changes in the user collection since the previous snapshot and
+// returns the new snapshot alongside the events. If the previous snapshot is nil, or the last
+// full fetch is older than the full interval, it fetches the entire collection, otherwise it
+// uses the modified since option to fetch only the changed items.
+func (bgg *BGG) SyncCollection(ctx context.Context, username string, prev *CollectionSnapshot, setters ...CollectionSyncOptionSetter) (*CollectionSnapshot, []CollectionEvent, error) {
+	opt := CollectionSyncOption{
+		fullInterval: 24 * time.Hour,
+		now:          time.Now,
+	}
+	for i := range setters {
+		setters[i](&opt)
+	}
+
+	if prev != nil && prev.Username != username {
+		return nil, nil, fmt.Errorf("snapshot is for %q not %q", prev.Username, username)
+	}
+
+	now := opt.now()
+	full := prev == nil || opt.fullInterval <= 0 || now.Sub(prev.FullFetchedAt) >= opt.fullInterval
+
+	options := append([]CollectionOptionSetter{SetStats(true)}, opt.options...)
+	if !full {
+		options = append(options, SetModifiedSince(prev.FetchedAt))
+	}
+
+	items, err := bgg.GetCollection(ctx, username, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := &CollectionSnapshot{
+		Username:  username,
+		Items:     make(map[int64]CollectionItem),
+		FetchedAt: now,
+	}
+	if prev != nil {
+		next.FullFetchedAt = prev.FullFetchedAt
+		if !full {
+			for key, item := range prev.Items {
+				next.Items[key] = item
+			}
+		}
+	}
+	if full {
+		next.FullFetchedAt = now
+	}
+
+	var events []CollectionEvent
+	for i := range items {
+		key := collectionKey(&items[i])
+		next.Items[key] = items[i]
+
+		var (
+			old CollectionItem
+			ok  bool
+		)
+		if prev != nil {
+			old, ok = prev.Items[key]
+		}
+
+		if !ok {
+			events = append(events, CollectionEvent{
+				Type: CollectionItemAdded,
+				Item: items[i],
+			})
+			continue
+		}
+
+		if changes := diffCollectionItem(&old, &items[i]); len(changes) > 0 {
+			events = append(events, CollectionEvent{
+				Type:    CollectionItemChanged,
+				Item:    items[i],
+				Changes: changes,
+			})
+		}
+	}
+
+	if full && prev != nil {
+		var removed []int64
+		for key := range prev.Items {
+			if _, ok := next.Items[key]; !ok {
+				removed = append(removed, key)
+			}
+		}
+		slices.Sort(removed)
+		for _, key := range removed {
+			events = append(events, CollectionEvent{
+				Type: CollectionItemRemoved,
+				Item: prev.Items[key],
+			})
+		}
+	}
+
+	return next, events, nil
+}
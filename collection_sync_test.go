@@ -0,0 +1,119 @@
+package gobgg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const collectionItemTemplate = `<item objecttype="thing" objectid="%[1]d" subtype="boardgame" collid="%[2]d">
+	<name sortindex="1">game-%[1]d</name>
+	<stats minplayers="2" maxplayers="4">
+		<rating value="%[3]s" />
+	</stats>
+	<status own="%[4]d" prevowned="0" fortrade="0" want="0" wanttoplay="0" wanttobuy="0" wishlist="0" preordered="0" lastmodified="2024-01-02 10:11:12" />
+	<numplays>%[5]d</numplays>
+</item>`
+
+func collectionBody(items ...string) string {
+	body := `<?xml version="1.0" encoding="utf-8" standalone="yes"?>
+<items totalitems="1" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">`
+	for i := range items {
+		body += items[i]
+	}
+
+	return body + "</items>"
+}
+
+func TestSyncCollection(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var (
+		full        string
+		incremental string
+		lastQuery   map[string]string
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://boardgamegeek.com"+collectionPath,
+		func(req *http.Request) (*http.Response, error) {
+			lastQuery = map[string]string{}
+			for key := range req.URL.Query() {
+				lastQuery[key] = req.URL.Query().Get(key)
+			}
+			if lastQuery["modifiedsince"] != "" {
+				return httpmock.NewStringResponse(200, incremental), nil
+			}
+			return httpmock.NewStringResponse(200, full), nil
+		},
+	)
+
+	ctx := context.Background()
+	bgg := NewBGGClient()
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	clock := func(opt *CollectionSyncOption) {
+		opt.now = func() time.Time { return now }
+	}
+
+	full = collectionBody(
+		fmt.Sprintf(collectionItemTemplate, 1, 101, "N/A", 1, 0),
+		fmt.Sprintf(collectionItemTemplate, 2, 102, "7", 1, 3),
+	)
+	snap, events, err := bgg.SyncCollection(ctx, "user", nil, clock)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "1", lastQuery["stats"])
+	assert.Empty(t, lastQuery["modifiedsince"])
+	for i := range events {
+		assert.Equal(t, CollectionItemAdded, events[i].Type)
+	}
+	require.Len(t, snap.Items, 2)
+	assert.Equal(t, 7.0, snap.Items[102].Rating)
+	assert.Equal(t, 3, snap.Items[102].NumPlays)
+
+	// Incremental, only the changed item is returned
+	now = now.Add(time.Hour)
+	incremental = collectionBody(
+		fmt.Sprintf(collectionItemTemplate, 2, 102, "8", 1, 4),
+		fmt.Sprintf(collectionItemTemplate, 3, 103, "N/A", 0, 1),
+	)
+	snap, events, err = bgg.SyncCollection(ctx, "user", snap, clock)
+	require.NoError(t, err)
+	assert.NotEmpty(t, lastQuery["modifiedsince"])
+	require.Len(t, events, 2)
+	assert.Equal(t, CollectionItemChanged, events[0].Type)
+	assert.Equal(t, []CollectionFieldChange{
+		{Field: CollectionFieldRating, Old: 7.0, New: 8.0},
+		{Field: CollectionFieldPlays, Old: 3, New: 4},
+	}, events[0].Changes)
+	assert.Equal(t, CollectionItemAdded, events[1].Type)
+	assert.Len(t, snap.Items, 3)
+
+	// Full fetch after the interval, item 1 is removed
+	now = now.Add(25 * time.Hour)
+	full = collectionBody(
+		fmt.Sprintf(collectionItemTemplate, 2, 102, "8", 0, 4),
+		fmt.Sprintf(collectionItemTemplate, 3, 103, "N/A", 0, 1),
+	)
+	snap, events, err = bgg.SyncCollection(ctx, "user", snap, clock)
+	require.NoError(t, err)
+	assert.Empty(t, lastQuery["modifiedsince"])
+	require.Len(t, events, 2)
+	assert.Equal(t, CollectionItemChanged, events[0].Type)
+	require.Len(t, events[0].Changes, 1)
+	assert.Equal(t, CollectionFieldStatus, events[0].Changes[0].Field)
+	assert.Equal(t, CollectionItemRemoved, events[1].Type)
+	assert.Equal(t, int64(1), events[1].Item.ID)
+	assert.Len(t, snap.Items, 2)
+	assert.Equal(t, now, snap.FullFetchedAt)
+
+	_, _, err = bgg.SyncCollection(ctx, "other", snap, clock)
+	require.Error(t, err)
+}
@@ -116,16 +116,8 @@ type collectionItems struct {
 				Stddev       SimpleString `xml:"stddev"`
 				Median       SimpleString `xml:"median"`
 				Ranks        struct {
-					Text string `xml:",chardata"`
-					Rank []struct {
-						Text         string `xml:",chardata"`
-						Type         string `xml:"type,attr"`
-						ID           string `xml:"id,attr"`
-						Name         string `xml:"name,attr"`
-						Friendlyname string `xml:"friendlyname,attr"`
-						Value        string `xml:"value,attr"`
-						Bayesaverage string `xml:"bayesaverage,attr"`
-					} `xml:"rank"`
+					Text string       `xml:",chardata"`
+					Rank []rankStruct `xml:"rank"`
 				} `xml:"ranks"`
 			} `xml:"rating"`
 		} `xml:"stats"`
@@ -161,7 +153,7 @@ type GetCollectionOptions struct {
 	subtype        string
 	excludesubtype string
 	// brief          bool
-	// stats          bool
+	stats        bool
 	options      []CollectionType
 	minrating    int
 	rating       int
@@ -175,7 +167,7 @@ type GetCollectionOptions struct {
 	modifiedsince *time.Time
 }
 
-func (c *GetCollectionOptions) toMap() map[string]string {
+func (c *GetCollectionOptions) toMap(loc *time.Location) map[string]string {
 	result := map[string]string{}
 
 	setIf := func(cond bool, key, value string) {
@@ -184,6 +176,7 @@ func (c *GetCollectionOptions) toMap() map[string]string {
 		}
 	}
 	setIf(c.version, "version", "1")
+	setIf(c.stats, "stats", "1")
 	setIf(c.subtype != "", "subtype", c.subtype)
 	setIf(c.excludesubtype != "", "excludesubtype", c.excludesubtype)
 	setIf(c.minbggrating > 0, "minbggrating", fmt.Sprint(c.minbggrating))
@@ -194,7 +187,8 @@ func (c *GetCollectionOptions) toMap() map[string]string {
 	setIf(c.maxplays > 0, "maxplays", fmt.Sprint(c.maxplays))
 	setIf(c.collID > 0, "collid", fmt.Sprint(c.collID))
 	if c.modifiedsince != nil {
-		result["modifiedsince"] = c.modifiedsince.Format("06-01-02")
+		// BGG accepts the full date time, and compares it with the server time
+		result["modifiedsince"] = c.modifiedsince.In(loc).Format(bggDateTimeFormat)
 	}
 
 	for i := range c.options {
@@ -236,6 +230,14 @@ func SetVersion(version bool) CollectionOptionSetter {
 	}
 }
 
+// SetStats returns ranking and rating stats for each item in the collection,
+// it is required to get the user rating of the item
+func SetStats(stats bool) CollectionOptionSetter {
+	return func(co *GetCollectionOptions) {
+		co.stats = stats
+	}
+}
+
 // SetSubType  Specifies which collection you want to retrieve.
 // TYPE may be boardgame, boardgameexpansion, boardgameaccessory,
 // rpgitem, rpgissue, or videogame; the default is boardgame
@@ -295,7 +297,8 @@ func SetMinBGGRating(rate int) CollectionOptionSetter {
 	}
 }
 
-// SetModifiedSince to set the modified since flag
+// SetModifiedSince to set the modified since flag, the time is converted to the BGG server
+// time (see SetServerLocation) and is sent with second precision
 func SetModifiedSince(t time.Time) CollectionOptionSetter {
 	return func(options *GetCollectionOptions) {
 		options.modifiedsince = &t
@@ -346,7 +349,7 @@ func (bgg *BGG) GetCollection(ctx context.Context, username string, options ...C
 		options[i](&opt)
 	}
 
-	args := opt.toMap()
+	args := opt.toMap(bgg.location)
 	args["username"] = username
 
 	u := bgg.buildURL(collectionPath, args)
@@ -389,7 +392,7 @@ func (bgg *BGG) GetCollection(ctx context.Context, username string, options ...C
 	defer resp.Body.Close()
 
 	var result collectionItems
-	if err = decode(resp.Body, &result); err != nil {
+	if err = bgg.decode(EndpointCollection, resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 
@@ -404,6 +407,21 @@ func (bgg *BGG) GetCollection(ctx context.Context, username string, options ...C
 			YearPublished:    int(safeInt(result.Item[i].Yearpublished)),
 			Thumbnail:        result.Item[i].Thumbnail,
 			Image:            result.Item[i].Image,
+			OriginalName:     result.Item[i].Originalname,
+			Rating:           safeFloat64(result.Item[i].Stats.Rating.Value),
+			NumPlays:         result.Item[i].Numplays,
+			Comment:          result.Item[i].Comment,
+			WishListComment:  result.Item[i].Wishlistcomment,
+			LastModified:     safeDateTime(result.Item[i].Status.Lastmodified, bgg.location),
+			MinPlayers:       int(safeInt(result.Item[i].Stats.Minplayers)),
+			MaxPlayers:       int(safeInt(result.Item[i].Stats.Maxplayers)),
+			PlayingTime:      int(safeInt(result.Item[i].Stats.Playingtime)),
+			MinPlayTime:      int(safeInt(result.Item[i].Stats.Minplaytime)),
+			MaxPlayTime:      int(safeInt(result.Item[i].Stats.Maxplaytime)),
+			NumOwned:         int(safeInt(result.Item[i].Stats.Numowned)),
+			Average:          safeFloat64(result.Item[i].Stats.Rating.Average.Value),
+			BayesAverage:     safeFloat64(result.Item[i].Stats.Rating.Bayesaverage.Value),
+			Ranks:            parseRanks(result.Item[i].Stats.Rating.Ranks.Rank),
 			CollectionStatus: statusToStringArray(&result.Item[i].Status, result.Item[i].Numplays),
 		}
 	}
@@ -2,10 +2,14 @@ package gobgg_test
 
 import (
 	"context"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/fzerorubigd/gobgg"
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +40,64 @@ func TestGetCollection(t *testing.T) {
 		require.ElementsMatch(t, games[col[i].ID], col[i].CollectionStatus)
 	}
 }
+
+// collectionResponder serves the recorded response in the file for the query
+func collectionResponder(t *testing.T, query, file string) {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	httpmock.RegisterResponderWithQuery(http.MethodGet, "https://boardgamegeek.com/xmlapi2/collection",
+		query, httpmock.NewBytesResponder(http.StatusOK, data))
+}
+
+func TestGetCollectionModifiedSince(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The responses are for a server in EST, the since time is sent in the server time zone
+	loc := time.FixedZone("EST", -5*60*60)
+	collectionResponder(t, "username=gobgg&stats=1", "testdata/collection.xml")
+	collectionResponder(t, "username=gobgg&modifiedsince=2024-01-01 23:59:59", "testdata/collection-20240101235959.xml")
+	collectionResponder(t, "username=gobgg&modifiedsince=2024-01-02 00:00:01", "testdata/collection-20240102000001.xml")
+	collectionResponder(t, "username=gobgg&modifiedsince=2024-01-02 00:59:59", "testdata/collection-20240102005959.xml")
+
+	ctx := context.Background()
+	bgg := gobgg.NewBGGClient(gobgg.SetServerLocation(loc))
+
+	all, err := bgg.GetCollection(ctx, "gobgg", gobgg.SetStats(true))
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, 8.0, all[0].Rating)
+	assert.Equal(t, 3, all[0].NumPlays)
+	assert.Equal(t, time.Date(2024, 1, 1, 23, 59, 58, 0, loc), all[0].LastModified)
+	assert.Zero(t, all[1].Rating)
+	rank, ok := all[1].RankIn("thematic")
+	require.True(t, ok)
+	assert.Equal(t, gobgg.Rank{Kind: gobgg.RankKindFamily, ID: 5496, Name: "thematic", FriendlyName: "Thematic Rank", Ranked: true, Value: 2, BayesAverage: 8.36}, rank)
+	_, ok = all[1].RankIn("wargames")
+	assert.False(t, ok)
+
+	// One second before midnight in the server time, but in UTC it is the next day
+	since := time.Date(2024, 1, 2, 4, 59, 59, 0, time.UTC)
+	items, err := bgg.GetCollection(ctx, "gobgg", gobgg.SetModifiedSince(since))
+	require.NoError(t, err)
+	ids := make([]int64, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+	assert.Equal(t, []int64{161936, 174430}, ids)
+
+	items, err = bgg.GetCollection(ctx, "gobgg", gobgg.SetModifiedSince(since.Add(2*time.Second)))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, int64(174430), items[0].ID)
+
+	items, err = bgg.GetCollection(ctx, "gobgg", gobgg.SetModifiedSince(since.Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Empty(t, items)
+
+	// Each request was sent with the expected query, an unexpected query has no responder
+	info := httpmock.GetCallCountInfo()
+	for _, since := range []string{"2024-01-01 23:59:59", "2024-01-02 00:00:01", "2024-01-02 00:59:59"} {
+		assert.Equal(t, 1, info["GET https://boardgamegeek.com/xmlapi2/collection?modifiedsince="+url.QueryEscape(since)+"&username=gobgg"], since)
+	}
+}
@@ -85,16 +85,8 @@ type Statistics struct {
 			Value string `xml:"value,attr"`
 		} `xml:"bayesaverage"`
 		Ranks struct {
-			Text string `xml:",chardata"`
-			Rank []struct {
-				Text         string `xml:",chardata"`
-				Type         string `xml:"type,attr"`
-				ID           string `xml:"id,attr"`
-				Name         string `xml:"name,attr"`
-				Friendlyname string `xml:"friendlyname,attr"`
-				Value        string `xml:"value,attr"`
-				Bayesaverage string `xml:"bayesaverage,attr"`
-			} `xml:"rank"`
+			Text string       `xml:",chardata"`
+			Rank []rankStruct `xml:"rank"`
 		} `xml:"ranks"`
 		Stddev struct {
 			Text  string `xml:",chardata"`
@@ -181,24 +173,75 @@ type Play struct {
 }
 
 type Item struct {
-	Name string   `json:"name,omitempty"`
-	Type ItemType `json:"type,omitempty"`
-	ID   int64    `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Type     ItemType   `json:"type,omitempty"`
+	ID       int64      `json:"id,omitempty"`
+	Subtypes []ItemType `json:"subtypes,omitempty"`
 }
 
 type Player struct {
-	UserName      string `json:"user_name,omitempty"`
-	UserID        string `json:"user_id,omitempty"`
-	Name          string `json:"name,omitempty"`
-	StartPosition string `json:"start_position,omitempty"`
-	Color         string `json:"color,omitempty"`
-	Score         int64  `json:"score,omitempty"`
-	New           bool   `json:"new,omitempty"`
-	Rating        string `json:"rating,omitempty"`
-	Win           bool   `json:"win,omitempty"`
+	UserName string `json:"user_name,omitempty"`
+	UserID   int64  `json:"user_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	// StartPosition is the numeric start position, zero when it is not set or is not a number
+	StartPosition int `json:"start_position,omitempty"`
+	// StartPositionText is the start position as it is entered in BGG
+	StartPositionText string `json:"start_position_text,omitempty"`
+	Color             string `json:"color,omitempty"`
+	// Score is the numeric score, zero when the score is not a number, like "DNF"
+	Score float64 `json:"score,omitempty"`
+	// ScoreText is the score as it is entered in BGG
+	ScoreText string `json:"score_text,omitempty"`
+	// NoScore is true when the player has no score, a zero Score without it is a real zero
+	NoScore bool    `json:"no_score,omitempty"`
+	New     bool    `json:"new,omitempty"`
+	Rating  float64 `json:"rating,omitempty"`
+	Win     bool    `json:"win,omitempty"`
+}
+
+// ScoreString returns the score text, or the numeric score if there is no text
+func (p *Player) ScoreString() string {
+	if p.ScoreText != "" {
+		return p.ScoreText
+	}
+
+	if p.NoScore {
+		return ""
+	}
+
+	return strconv.FormatFloat(p.Score, 'f', -1, 64)
+}
+
+// NumericScore returns the numeric score and true if the player has a numeric score
+func (p *Player) NumericScore() (float64, bool) {
+	if p.ScoreText == "" {
+		return p.Score, !p.NoScore
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(p.ScoreText), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return f, true
 }
 
-func nameStructToString(args []NameStruct) (string, []string) {
+// StartPositionString returns the start position text, or the numeric position if there is no text
+func (p *Player) StartPositionString() string {
+	if p.StartPositionText != "" {
+		return p.StartPositionText
+	}
+
+	if p.StartPosition == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(p.StartPosition)
+}
+
+// nameStructToString returns the primary and the alternate names, the unknown name types are
+// logged, or reported as the schema drift (by the schema check) when there is a drift handler
+func (bgg *BGG) nameStructToString(args []NameStruct) (string, []string) {
 	var (
 		primary   string
 		alternate []string
@@ -210,7 +253,7 @@ func nameStructToString(args []NameStruct) (string, []string) {
 			primary = name.Value
 		case name.Type == "alternate":
 			alternate = append(alternate, name.Value)
-		default:
+		case bgg.drift == nil:
 			log.Printf("Name type %q is not handled, please report it as an issue", name.Type)
 		}
 	}
@@ -250,12 +293,16 @@ func safeIntInterface(in any) int64 {
 		return int64(t)
 	case byte:
 		return int64(t)
+	case float64:
+		// JSON numbers
+		return int64(t)
 	default:
 		return 0
 	}
 }
 
 func safeFloat64(str string) float64 {
+	str = strings.TrimSpace(str)
 	if str == "" {
 		return 0
 	}
@@ -273,6 +320,15 @@ func safeDate(str string) time.Time {
 	return ts
 }
 
+func safeDateTime(str string, loc *time.Location) time.Time {
+	ts, err := time.ParseInLocation(bggDateTimeFormat, str, loc)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return ts
+}
+
 type bggError struct {
 	XMLName xml.Name `xml:"error"`
 	Text    string   `xml:",chardata"`
@@ -325,12 +381,12 @@ func getID(url string, typ string) int64 {
 }
 
 func getPreviousDay(t time.Time, day time.Weekday) time.Time {
-	w := t.Weekday()
-	if w == day {
-		return t
+	diff := int(t.Weekday() - day)
+	if diff < 0 {
+		diff += 7
 	}
 
-	return t.AddDate(0, 0, int(day-w))
+	return t.AddDate(0, 0, -diff)
 }
 
 func getStartOfTheMonth(t time.Time) time.Time {
@@ -0,0 +1,203 @@
+package export
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// BGStatsTimeFormat is the date time format used in the BG Stats backup
+const BGStatsTimeFormat = "2006-01-02 15:04:05"
+
+// BGStats is the BG Stats app backup file, only the fields that can be mapped to BGG are here
+type BGStats struct {
+	UserInfo  BGStatsUserInfo   `json:"userInfo"`
+	Games     []BGStatsGame     `json:"games"`
+	Players   []BGStatsPlayer   `json:"players"`
+	Locations []BGStatsLocation `json:"locations"`
+	Plays     []BGStatsPlay     `json:"plays"`
+}
+
+// BGStatsUserInfo is the owner of the backup
+type BGStatsUserInfo struct {
+	MeRefID     int64  `json:"meRefId,omitempty"`
+	BGGUsername string `json:"bggUsername,omitempty"`
+}
+
+// BGStatsGame is a game in the BG Stats backup
+type BGStatsGame struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	BGGID       int64  `json:"bggId"`
+	BGGName     string `json:"bggName,omitempty"`
+	IsExpansion bool   `json:"isExpansion"`
+}
+
+// BGStatsPlayer is a player in the BG Stats backup
+type BGStatsPlayer struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	BGGUsername string `json:"bggUsername,omitempty"`
+	IsAnonymous bool   `json:"isAnonymous"`
+}
+
+// BGStatsLocation is a location in the BG Stats backup
+type BGStatsLocation struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// BGStatsPlayerScore is a single player result in a play
+type BGStatsPlayerScore struct {
+	PlayerRefID int64  `json:"playerRefId"`
+	Score       string `json:"score"`
+	Winner      bool   `json:"winner"`
+	NewPlayer   bool   `json:"newPlayer"`
+	StartPlayer bool   `json:"startPlayer"`
+	SeatOrder   int    `json:"seatOrder,omitempty"`
+	Role        string `json:"role,omitempty"`
+}
+
+// BGStatsPlay is a single play in the BG Stats backup
+type BGStatsPlay struct {
+	GameRefID     int64                `json:"gameRefId"`
+	LocationRefID int64                `json:"locationRefId,omitempty"`
+	PlayDate      string               `json:"playDate"`
+	DurationMin   int                  `json:"durationMin"`
+	Ignored       bool                 `json:"ignored"`
+	Incomplete    bool                 `json:"incomplete,omitempty"`
+	BGGID         int64                `json:"bggId,omitempty"`
+	Comments      string               `json:"comments,omitempty"`
+	PlayerScores  []BGStatsPlayerScore `json:"playerScores"`
+}
+
+type bgStatsBuilder struct {
+	result    *BGStats
+	games     map[int64]int64
+	players   map[string]int64
+	locations map[string]int64
+}
+
+func playerKey(p *gobgg.Player) string {
+	if p.UserName != "" {
+		return "u:" + strings.ToLower(p.UserName)
+	}
+
+	return "n:" + strings.ToLower(p.Name)
+}
+
+func (b *bgStatsBuilder) game(item *gobgg.Item) int64 {
+	if id, ok := b.games[item.ID]; ok {
+		return id
+	}
+
+	id := int64(len(b.result.Games) + 1)
+	b.games[item.ID] = id
+	b.result.Games = append(b.result.Games, BGStatsGame{
+		ID:          id,
+		Name:        item.Name,
+		BGGID:       item.ID,
+		BGGName:     item.Name,
+		IsExpansion: slices.Contains(item.Subtypes, gobgg.BoardGameExpansionType),
+	})
+
+	return id
+}
+
+func (b *bgStatsBuilder) player(p *gobgg.Player) int64 {
+	key := playerKey(p)
+	if id, ok := b.players[key]; ok {
+		return id
+	}
+
+	id := int64(len(b.result.Players) + 1)
+	b.players[key] = id
+	name := p.Name
+	if name == "" {
+		name = p.UserName
+	}
+	b.result.Players = append(b.result.Players, BGStatsPlayer{
+		ID:          id,
+		Name:        name,
+		BGGUsername: p.UserName,
+		IsAnonymous: name == "" || strings.EqualFold(name, "anonymous player"),
+	})
+
+	return id
+}
+
+func (b *bgStatsBuilder) location(name string) int64 {
+	if name == "" {
+		return 0
+	}
+	if id, ok := b.locations[name]; ok {
+		return id
+	}
+
+	id := int64(len(b.result.Locations) + 1)
+	b.locations[name] = id
+	b.result.Locations = append(b.result.Locations, BGStatsLocation{
+		ID:   id,
+		Name: name,
+	})
+
+	return id
+}
+
+// ToBGStats converts the plays into the BG Stats backup structure, the games are deduplicated
+// by BGG id and the players by their BGG username (or name if there is no username)
+func ToBGStats(username string, plays []gobgg.Play) *BGStats {
+	b := bgStatsBuilder{
+		result: &BGStats{
+			UserInfo:  BGStatsUserInfo{BGGUsername: username},
+			Games:     []BGStatsGame{},
+			Players:   []BGStatsPlayer{},
+			Locations: []BGStatsLocation{},
+			Plays:     make([]BGStatsPlay, 0, len(plays)),
+		},
+		games:     make(map[int64]int64),
+		players:   make(map[string]int64),
+		locations: make(map[string]int64),
+	}
+
+	if username != "" {
+		b.result.UserInfo.MeRefID = b.player(&gobgg.Player{UserName: username})
+	}
+
+	for i := range plays {
+		play := &plays[i]
+		bp := BGStatsPlay{
+			GameRefID:     b.game(&play.Item),
+			LocationRefID: b.location(play.Location),
+			PlayDate:      play.Date.Format(BGStatsTimeFormat),
+			DurationMin:   int(play.Length.Minutes()),
+			Incomplete:    play.Incomplete,
+			BGGID:         play.ID,
+			Comments:      play.Comment,
+			PlayerScores:  make([]BGStatsPlayerScore, 0, len(play.Players)),
+		}
+
+		for j := range play.Players {
+			p := &play.Players[j]
+			seat := p.StartPosition
+			bp.PlayerScores = append(bp.PlayerScores, BGStatsPlayerScore{
+				PlayerRefID: b.player(p),
+				Score:       p.ScoreString(),
+				Winner:      p.Win,
+				NewPlayer:   p.New,
+				StartPlayer: seat == 1,
+				SeatOrder:   seat,
+				Role:        p.Color,
+			})
+		}
+
+		// BG Stats has no quantity, each play in the quantity is a separate play
+		for n := int(math.Max(1, math.Round(play.Quantity))); n > 0; n-- {
+			b.result.Plays = append(b.result.Plays, bp)
+		}
+	}
+
+	return b.result
+}
@@ -0,0 +1,129 @@
+// Package export writes the gobgg results in the formats other tools understand, like the BGG
+// collection CSV, a play log CSV and the BG Stats app JSON
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// CollectionHeader is the header of the BGG collection CSV export
+var CollectionHeader = []string{
+	"objectname", "objectid", "rating", "numplays", "weight", "own", "fortrade", "want",
+	"wanttobuy", "wanttoplay", "prevowned", "preordered", "wishlist", "wishlistpriority",
+	"wishlistcomment", "comment", "conditiontext", "haspartslist", "wantpartslist", "collid",
+	"baverage", "average", "avgweight", "rank", "numowned", "objecttype", "originalname",
+	"minplayers", "maxplayers", "playingtime", "maxplaytime", "minplaytime", "yearpublished",
+	"bggrecplayers", "bggbestplayers", "bggrecagerange", "bgglanguagedependence", "publisherid",
+	"imageid", "year", "language", "other", "itemtype", "barcode", "pricepaid", "pp_currency",
+	"currvalue", "cv_currency", "acquisitiondate", "acquiredfrom", "quantity", "privatecomment",
+	"invlocation", "invdate", "version_publishers", "version_languages", "version_yearpublished",
+	"version_nickname",
+}
+
+var wishListPriority = map[string]int{
+	"musthave":        gobgg.WishListPriorityMustHave,
+	"lovetohave":      gobgg.WishListPriorityLoveToHave,
+	"liketohave":      gobgg.WishListPriorityLikeToHave,
+	"thinkingaboutit": gobgg.WishListPriorityThinkingAboutIt,
+	"donotbuy":        gobgg.WishListPriorityDoNotBuy,
+}
+
+func boolField(status []string, typ gobgg.CollectionType) string {
+	if slices.Contains(status, string(typ)) {
+		return "1"
+	}
+
+	return "0"
+}
+
+func intField(i int) string {
+	if i == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(i)
+}
+
+func floatField(f float64) string {
+	if f == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func collectionRecord(item *gobgg.CollectionItem) []string {
+	var priority int
+	for _, st := range item.CollectionStatus {
+		if p, ok := wishListPriority[st]; ok {
+			priority = p
+		}
+	}
+
+	itemType := "standalone"
+	if item.Type == gobgg.BoardGameExpansionType {
+		itemType = "expansion"
+	}
+
+	record := map[string]string{
+		"objectname":       item.Name,
+		"objectid":         fmt.Sprint(item.ID),
+		"rating":           floatField(item.Rating),
+		"numplays":         fmt.Sprint(item.NumPlays),
+		"own":              boolField(item.CollectionStatus, gobgg.CollectionTypeOwn),
+		"fortrade":         boolField(item.CollectionStatus, gobgg.CollectionTypeTrade),
+		"want":             boolField(item.CollectionStatus, gobgg.CollectionTypeWant),
+		"wanttobuy":        boolField(item.CollectionStatus, gobgg.CollectionTypeWantToBuy),
+		"wanttoplay":       boolField(item.CollectionStatus, gobgg.CollectionTypeWantToPlay),
+		"prevowned":        boolField(item.CollectionStatus, gobgg.CollectionTypePrevOwned),
+		"preordered":       boolField(item.CollectionStatus, gobgg.CollectionTypePreorder),
+		"wishlist":         boolField(item.CollectionStatus, gobgg.CollectionTypeWishList),
+		"wishlistpriority": intField(priority),
+		"wishlistcomment":  item.WishListComment,
+		"comment":          item.Comment,
+		"collid":           fmt.Sprint(item.CollID),
+		"baverage":         floatField(item.BayesAverage),
+		"average":          floatField(item.Average),
+		"numowned":         intField(item.NumOwned),
+		"objecttype":       "thing",
+		"originalname":     item.OriginalName,
+		"minplayers":       intField(item.MinPlayers),
+		"maxplayers":       intField(item.MaxPlayers),
+		"playingtime":      intField(item.PlayingTime),
+		"maxplaytime":      intField(item.MaxPlayTime),
+		"minplaytime":      intField(item.MinPlayTime),
+		"yearpublished":    intField(item.YearPublished),
+		"itemtype":         itemType,
+	}
+
+	result := make([]string, len(CollectionHeader))
+	for i := range CollectionHeader {
+		result[i] = record[CollectionHeader[i]]
+	}
+
+	return result
+}
+
+// CollectionCSV writes the collection in the same layout as the BGG collection CSV export,
+// the columns that are not available in the collection item are left empty
+func CollectionCSV(w io.Writer, items []gobgg.CollectionItem) error {
+	wcsv := csv.NewWriter(w)
+	if err := wcsv.Write(CollectionHeader); err != nil {
+		return fmt.Errorf("write header failed: %w", err)
+	}
+
+	for i := range items {
+		if err := wcsv.Write(collectionRecord(&items[i])); err != nil {
+			return fmt.Errorf("write record failed: %w", err)
+		}
+	}
+
+	wcsv.Flush()
+	return wcsv.Error()
+}
@@ -0,0 +1,148 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func column(t *testing.T, header []string, record []string, name string) string {
+	for i := range header {
+		if header[i] == name {
+			return record[i]
+		}
+	}
+	t.Fatalf("column %q not found", name)
+	return ""
+}
+
+func TestCollectionCSV(t *testing.T) {
+	items := []gobgg.CollectionItem{
+		{
+			ID:               174430,
+			CollID:           10,
+			Name:             "Gloomhaven",
+			Type:             gobgg.BoardGameType,
+			YearPublished:    2017,
+			Rating:           9.5,
+			NumPlays:         12,
+			CollectionStatus: []string{"own", "wishlist", "musthave", "played"},
+		},
+		{
+			ID:               1,
+			Name:             "Some, \"expansion\"",
+			Type:             gobgg.BoardGameExpansionType,
+			CollectionStatus: []string{"prevowned"},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, CollectionCSV(buf, items))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, CollectionHeader, records[0])
+
+	assert.Equal(t, "Gloomhaven", column(t, records[0], records[1], "objectname"))
+	assert.Equal(t, "174430", column(t, records[0], records[1], "objectid"))
+	assert.Equal(t, "9.5", column(t, records[0], records[1], "rating"))
+	assert.Equal(t, "12", column(t, records[0], records[1], "numplays"))
+	assert.Equal(t, "1", column(t, records[0], records[1], "own"))
+	assert.Equal(t, "1", column(t, records[0], records[1], "wishlist"))
+	assert.Equal(t, "1", column(t, records[0], records[1], "wishlistpriority"))
+	assert.Equal(t, "0", column(t, records[0], records[1], "prevowned"))
+	assert.Equal(t, "standalone", column(t, records[0], records[1], "itemtype"))
+
+	assert.Equal(t, "Some, \"expansion\"", column(t, records[0], records[2], "objectname"))
+	assert.Equal(t, "", column(t, records[0], records[2], "rating"))
+	assert.Equal(t, "1", column(t, records[0], records[2], "prevowned"))
+	assert.Equal(t, "expansion", column(t, records[0], records[2], "itemtype"))
+}
+
+func testPlays() []gobgg.Play {
+	date := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	return []gobgg.Play{
+		{
+			ID:         100,
+			Date:       date,
+			Quantity:   1,
+			Length:     90 * time.Minute,
+			NowInStats: true,
+			Location:   "Home",
+			Comment:    "Fun",
+			Item:       gobgg.Item{ID: 174430, Name: "Gloomhaven", Type: "thing"},
+			Players: []gobgg.Player{
+				{UserName: "me", Name: "Me", StartPosition: 1, Score: 10, Win: true},
+				{Name: "Friend|One", StartPosition: 2, Score: 8, New: true},
+			},
+		},
+		{
+			ID:       101,
+			Date:     date.AddDate(0, 0, 1),
+			Quantity: 2,
+			Location: "Home",
+			Item:     gobgg.Item{ID: 174430, Name: "Gloomhaven", Type: "thing"},
+			Players: []gobgg.Player{
+				{UserName: "ME", Name: "Me"},
+			},
+		},
+	}
+}
+
+func TestPlaysCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, PlaysCSV(buf, testPlays()))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, PlaysHeader, records[0])
+	assert.Equal(t, []string{
+		"100", "2024-03-04", "174430", "Gloomhaven", "thing", "1", "90", "0", "1", "Home", "Fun",
+		"me||Me|1||10|0||1;||Friend/One|2||8|1||0",
+	}, records[1])
+	assert.Equal(t, "2", column(t, records[0], records[2], "quantity"))
+}
+
+func TestPlaysBGStats(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, PlaysBGStats(buf, "me", testPlays()))
+
+	var result BGStats
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "me", result.UserInfo.BGGUsername)
+	assert.Equal(t, int64(1), result.UserInfo.MeRefID)
+	require.Len(t, result.Games, 1)
+	assert.Equal(t, int64(174430), result.Games[0].BGGID)
+	require.Len(t, result.Players, 2)
+	require.Len(t, result.Locations, 1)
+	// The second play has the quantity 2
+	require.Len(t, result.Plays, 3)
+	assert.Equal(t, result.Plays[1], result.Plays[2])
+
+	play := result.Plays[0]
+	assert.Equal(t, "2024-03-04 00:00:00", play.PlayDate)
+	assert.Equal(t, 90, play.DurationMin)
+	assert.Equal(t, int64(100), play.BGGID)
+	require.Len(t, play.PlayerScores, 2)
+	assert.Equal(t, int64(1), play.PlayerScores[0].PlayerRefID)
+	assert.True(t, play.PlayerScores[0].Winner)
+	assert.True(t, play.PlayerScores[0].StartPlayer)
+	assert.Equal(t, "8", play.PlayerScores[1].Score)
+	assert.True(t, play.PlayerScores[1].NewPlayer)
+	// The username is case insensitive
+	assert.Equal(t, int64(1), result.Plays[1].PlayerScores[0].PlayerRefID)
+
+	// BGG lists both subtypes for the expansions
+	expansion := ToBGStats("me", []gobgg.Play{{Quantity: 1, Item: gobgg.Item{ID: 1, Name: "Expansion",
+		Subtypes: []gobgg.ItemType{gobgg.BoardGameType, gobgg.BoardGameExpansionType}}}})
+	assert.True(t, expansion.Games[0].IsExpansion)
+	assert.False(t, result.Games[0].IsExpansion)
+}
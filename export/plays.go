@@ -0,0 +1,104 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// PlaysHeader is the header of the play log CSV
+var PlaysHeader = []string{
+	"playid", "date", "objectid", "objectname", "objecttype", "quantity", "length",
+	"incomplete", "nowinstats", "location", "comments", "players",
+}
+
+// PlayerFields is the order of the fields for each player in the players column of the play
+// log CSV. The fields are separated by "|" and the players by ";"
+var PlayerFields = []string{
+	"username", "userid", "name", "startposition", "color", "score", "new", "rating", "win",
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+
+	return "0"
+}
+
+var playerEscaper = strings.NewReplacer("|", "/", ";", ",")
+
+func playerRecord(p *gobgg.Player) string {
+	fields := []string{
+		p.UserName,
+		intField(int(p.UserID)),
+		p.Name,
+		p.StartPositionString(),
+		p.Color,
+		p.ScoreString(),
+		boolString(p.New),
+		floatField(p.Rating),
+		boolString(p.Win),
+	}
+	for i := range fields {
+		fields[i] = playerEscaper.Replace(fields[i])
+	}
+
+	return strings.Join(fields, "|")
+}
+
+func playRecord(p *gobgg.Play) []string {
+	players := make([]string, len(p.Players))
+	for i := range p.Players {
+		players[i] = playerRecord(&p.Players[i])
+	}
+
+	return []string{
+		fmt.Sprint(p.ID),
+		p.Date.Format("2006-01-02"),
+		fmt.Sprint(p.Item.ID),
+		p.Item.Name,
+		string(p.Item.Type),
+		strconv.FormatFloat(p.Quantity, 'f', -1, 64),
+		fmt.Sprint(int(p.Length.Minutes())),
+		boolString(p.Incomplete),
+		boolString(p.NowInStats),
+		p.Location,
+		p.Comment,
+		strings.Join(players, ";"),
+	}
+}
+
+// PlaysCSV writes the plays in the play log CSV format, one row per play
+func PlaysCSV(w io.Writer, plays []gobgg.Play) error {
+	wcsv := csv.NewWriter(w)
+	if err := wcsv.Write(PlaysHeader); err != nil {
+		return fmt.Errorf("write header failed: %w", err)
+	}
+
+	for i := range plays {
+		if err := wcsv.Write(playRecord(&plays[i])); err != nil {
+			return fmt.Errorf("write record failed: %w", err)
+		}
+	}
+
+	wcsv.Flush()
+	return wcsv.Error()
+}
+
+// PlaysBGStats writes the plays in the BG Stats app backup format, the username is used to
+// mark the owner of the export (it can be empty)
+func PlaysBGStats(w io.Writer, username string, plays []gobgg.Play) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(ToBGStats(username, plays)); err != nil {
+		return fmt.Errorf("encoding JSON failed: %w", err)
+	}
+
+	return nil
+}
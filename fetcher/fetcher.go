@@ -0,0 +1,472 @@
+package fetcher
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+const (
+	defaultWorkers = 4
+	// maxThingIDs is the maximum number of ids in a single thing request
+	maxThingIDs = 20
+)
+
+// ErrInvalidJob is returned for the jobs with an unknown kind or without the required fields
+var ErrInvalidJob = errors.New("invalid job")
+
+// JobKind is the kind of the job
+type JobKind string
+
+const (
+	// JobThing fetches the things (up to 20 ids) with the statistics
+	JobThing JobKind = "thing"
+	// JobCollection fetches the collection of a user
+	JobCollection JobKind = "collection"
+	// JobPlays fetches a single page of the plays of a user or a game
+	JobPlays JobKind = "plays"
+	// JobUser fetches the user profile
+	JobUser JobKind = "user"
+)
+
+// Job is a single request to the BGG
+type Job struct {
+	Kind JobKind `json:"kind"`
+	// IDs are the thing ids, or the game id for the plays of a game
+	IDs      []int64 `json:"ids,omitempty"`
+	Username string  `json:"username,omitempty"`
+	// Page is the plays page, starts from 1
+	Page int `json:"page,omitempty"`
+}
+
+// ThingJobs splits the ids into the thing jobs, 20 ids in each job
+func ThingJobs(ids ...int64) []Job {
+	var result []Job
+	for start := 0; start < len(ids); start += maxThingIDs {
+		end := min(start+maxThingIDs, len(ids))
+		result = append(result, Job{Kind: JobThing, IDs: ids[start:end:end]})
+	}
+
+	return result
+}
+
+// CollectionJob is the job to fetch the collection of the user
+func CollectionJob(username string) Job {
+	return Job{Kind: JobCollection, Username: username}
+}
+
+// PlaysJob is the job to fetch a page of the user plays, the Total in the first page can be used
+// to find the number of the pages (100 plays in each page)
+func PlaysJob(username string, page int) Job {
+	return Job{Kind: JobPlays, Username: username, Page: page}
+}
+
+// GamePlaysJob is the job to fetch a page of the plays of a game
+func GamePlaysJob(id int64, page int) Job {
+	return Job{Kind: JobPlays, IDs: []int64{id}, Page: page}
+}
+
+// UserJob is the job to fetch the user profile
+func UserJob(username string) Job {
+	return Job{Kind: JobUser, Username: username}
+}
+
+// Jobs returns a closed channel with the jobs in it, for when the jobs are known in advance
+func Jobs(jobs ...Job) <-chan Job {
+	ch := make(chan Job, len(jobs))
+	for i := range jobs {
+		ch <- jobs[i]
+	}
+	close(ch)
+
+	return ch
+}
+
+// Key is the job key in the checkpoint file, the usernames are case-insensitive and the order of
+// the ids does not matter. The collection options are not in the key, so a checkpoint belongs to
+// one set of the collection options
+func (j *Job) Key() string {
+	sorted := slices.Sorted(slices.Values(j.IDs))
+	ids := make([]string, len(sorted))
+	for i := range sorted {
+		ids[i] = strconv.FormatInt(sorted[i], 10)
+	}
+	subject := strings.ToLower(j.Username)
+	if len(ids) > 0 {
+		subject += "#" + strings.Join(ids, ",")
+	}
+
+	switch j.Kind {
+	case JobThing:
+		return fmt.Sprintf("%s:%s", j.Kind, strings.Join(ids, ","))
+	case JobPlays:
+		return fmt.Sprintf("%s:%s:%d", j.Kind, subject, max(j.Page, 1))
+	}
+
+	return fmt.Sprintf("%s:%s", j.Kind, subject)
+}
+
+func (j *Job) validate() error {
+	switch j.Kind {
+	case JobThing:
+		if len(j.IDs) == 0 || len(j.IDs) > maxThingIDs {
+			return fmt.Errorf("%w: a thing job needs 1 to %d ids", ErrInvalidJob, maxThingIDs)
+		}
+	case JobCollection, JobUser:
+		if j.Username == "" {
+			return fmt.Errorf("%w: a %s job needs the username", ErrInvalidJob, j.Kind)
+		}
+	case JobPlays:
+		if (j.Username == "" && len(j.IDs) == 0) || len(j.IDs) > 1 {
+			return fmt.Errorf("%w: a plays job needs the username, one game id or both", ErrInvalidJob)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidJob, j.Kind)
+	}
+
+	return nil
+}
+
+// Result is the result of a job, only the field for the job kind is set
+type Result struct {
+	Job        Job
+	Things     []gobgg.ThingResult
+	Collection []gobgg.CollectionItem
+	Plays      *gobgg.Plays
+	User       *gobgg.User
+	// Err is the error of the job, the failed jobs are not in the checkpoint, so they are tried
+	// again on resume
+	Err error
+
+	skipped bool
+}
+
+// Progress is the state of the run, it is reported after each job
+type Progress struct {
+	// Done is the number of the succeeded jobs
+	Done int
+	// Failed is the number of the failed jobs
+	Failed int
+	// Skipped is the number of the jobs that are in the checkpoint, or are repeated in the input
+	// while the same job is running or is already done in this run
+	Skipped int
+	Elapsed time.Duration
+}
+
+// Rate is the number of the fetched (done or failed) jobs per second
+func (p Progress) Rate() float64 {
+	if p.Elapsed <= 0 {
+		return 0
+	}
+
+	return float64(p.Done+p.Failed) / p.Elapsed.Seconds()
+}
+
+// Fetcher runs the jobs over a number of workers, all the requests go through the same client,
+// so they share its limiter and retry policy (without a limiter on the client, the workers call
+// BGG as fast as they can, use gobgg.SetLimiter and gobgg.SetRetryPolicy)
+type Fetcher struct {
+	bgg        *gobgg.BGG
+	workers    int
+	checkpoint string
+	progress   func(Progress)
+	collection []gobgg.CollectionOptionSetter
+	now        func() time.Time
+}
+
+// OptionSetter is the option setter for the fetcher
+type OptionSetter func(*Fetcher)
+
+// Workers sets the number of the concurrent workers, default is 4
+func Workers(n int) OptionSetter {
+	return func(f *Fetcher) {
+		f.workers = n
+	}
+}
+
+// Checkpoint sets a file to keep track of the finished jobs, so an interrupted run can be resumed
+// by running it again with the same file and jobs. The collection options are not recorded, use a
+// new file when they change
+func Checkpoint(path string) OptionSetter {
+	return func(f *Fetcher) {
+		f.checkpoint = path
+	}
+}
+
+// OnProgress sets a function that is called after each job with the current progress, it is
+// called from a single goroutine
+func OnProgress(fn func(Progress)) OptionSetter {
+	return func(f *Fetcher) {
+		f.progress = fn
+	}
+}
+
+// CollectionOptions sets the options for all the collection jobs
+func CollectionOptions(opts ...gobgg.CollectionOptionSetter) OptionSetter {
+	return func(f *Fetcher) {
+		f.collection = append(f.collection, opts...)
+	}
+}
+
+// New creates a new fetcher
+func New(bgg *gobgg.BGG, opts ...OptionSetter) *Fetcher {
+	f := &Fetcher{
+		bgg:     bgg,
+		workers: defaultWorkers,
+		now:     time.Now,
+	}
+
+	for i := range opts {
+		opts[i](f)
+	}
+
+	if f.workers <= 0 {
+		f.workers = defaultWorkers
+	}
+
+	return f
+}
+
+func (f *Fetcher) do(ctx context.Context, r *Result) error {
+	var err error
+	job := &r.Job
+	switch job.Kind {
+	case JobThing:
+		r.Things, err = f.bgg.GetThings(ctx, gobgg.GetThingIDs(job.IDs...))
+	case JobCollection:
+		r.Collection, err = f.bgg.GetCollection(ctx, job.Username, f.collection...)
+	case JobPlays:
+		opts := []gobgg.PlaysOptionSetter{gobgg.SetPageNumber(max(job.Page, 1))}
+		if job.Username != "" {
+			opts = append(opts, gobgg.SetUserName(job.Username))
+		}
+		if len(job.IDs) > 0 {
+			opts = append(opts, gobgg.SetGameID(int(job.IDs[0])))
+		}
+		r.Plays, err = f.bgg.Plays(ctx, opts...)
+	case JobUser:
+		r.User, err = f.bgg.GetUser(ctx, job.Username)
+	}
+
+	return err
+}
+
+func (f *Fetcher) fetch(ctx context.Context, job Job) *Result {
+	r := &Result{Job: job}
+	if r.Err = job.validate(); r.Err == nil {
+		r.Err = f.do(ctx, r)
+	}
+
+	return r
+}
+
+// keySet is the set of the job keys that are shared between the feeder and the results loop
+type keySet struct {
+	lock sync.Mutex
+	keys map[string]bool
+}
+
+func newKeySet() *keySet {
+	return &keySet{keys: make(map[string]bool)}
+}
+
+// add adds the key to the set, it returns false if the key is already in the set
+func (s *keySet) add(key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.keys[key] {
+		return false
+	}
+	s.keys[key] = true
+
+	return true
+}
+
+func (s *keySet) remove(key string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.keys, key)
+}
+
+func readCheckpoint(path string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint failed: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			result[line] = true
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// Run reads the jobs until the channel is closed and runs them over the workers. The handler is
+// called for each finished job (including the failed ones, with Err set) from a single goroutine,
+// and the job is written to the checkpoint only after the handler returns, so a job is never lost.
+// An error from the handler stops the run. The failures on a single job do not stop the run (a
+// failed job that is repeated in the input is fetched again), the returned error is only for the
+// fatal errors and the context cancellation
+func (f *Fetcher) Run(ctx context.Context, jobs <-chan Job, handler func(*Result) error) (Progress, error) {
+	var (
+		progress Progress
+		done     = make(map[string]bool)
+		cp       *os.File
+		err      error
+	)
+	if f.checkpoint != "" {
+		if done, err = readCheckpoint(f.checkpoint); err != nil {
+			return progress, err
+		}
+
+		cp, err = os.OpenFile(f.checkpoint, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return progress, fmt.Errorf("open checkpoint failed: %w", err)
+		}
+		defer cp.Close()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		pending = make(chan Job)
+		results = make(chan *Result)
+		queued  = newKeySet()
+	)
+	send := func(r *Result) bool {
+		select {
+		case results <- r:
+			return true
+		case <-runCtx.Done():
+			return false
+		}
+	}
+
+	// The jobs in the checkpoint and the jobs that are queued in this run are skipped, the failed
+	// jobs are removed from the queued set, so they are fetched again if they are repeated
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(pending)
+		for {
+			var (
+				job Job
+				ok  bool
+			)
+			select {
+			case job, ok = <-jobs:
+			case <-runCtx.Done():
+				return
+			}
+			if !ok {
+				return
+			}
+
+			key := job.Key()
+			if done[key] || !queued.add(key) {
+				if !send(&Result{Job: job, skipped: true}) {
+					return
+				}
+				continue
+			}
+
+			select {
+			case pending <- job:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < f.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range pending {
+				r := f.fetch(runCtx, job)
+				if runCtx.Err() != nil {
+					// Canceled, the job is fetched again on resume
+					return
+				}
+				if !send(r) {
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	start := f.now()
+	for r := range results {
+		if !r.skipped {
+			if r.Err != nil {
+				queued.remove(r.Job.Key())
+			}
+			if err = f.finish(r, handler, cp); err != nil {
+				cancel()
+				for range results { // wait for the workers to stop
+				}
+				return progress, err
+			}
+		}
+
+		switch {
+		case r.skipped:
+			progress.Skipped++
+		case r.Err != nil:
+			progress.Failed++
+		default:
+			progress.Done++
+		}
+
+		progress.Elapsed = f.now().Sub(start)
+		if f.progress != nil {
+			f.progress(progress)
+		}
+	}
+
+	return progress, ctx.Err()
+}
+
+func (f *Fetcher) finish(r *Result, handler func(*Result) error, cp *os.File) error {
+	if handler != nil {
+		if err := handler(r); err != nil {
+			return fmt.Errorf("handle %s failed: %w", r.Job.Key(), err)
+		}
+	}
+
+	if r.Err != nil || cp == nil {
+		return nil
+	}
+
+	if _, err := fmt.Fprintln(cp, r.Job.Key()); err != nil {
+		return fmt.Errorf("write checkpoint failed: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/bggtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countLimiter struct {
+	n atomic.Int64
+}
+
+func (l *countLimiter) Take() time.Time {
+	l.n.Add(1)
+	return time.Now()
+}
+
+func TestJobs(t *testing.T) {
+	ids := make([]int64, 45)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	jobs := ThingJobs(ids...)
+	require.Len(t, jobs, 3)
+	assert.Len(t, jobs[0].IDs, 20)
+	assert.Equal(t, []int64{41, 42, 43, 44, 45}, jobs[2].IDs)
+
+	assert.Equal(t, "thing:1,2,3", (&Job{Kind: JobThing, IDs: []int64{1, 2, 3}}).Key())
+	assert.Equal(t, "thing:1,2,3", (&Job{Kind: JobThing, IDs: []int64{3, 1, 2}}).Key())
+	assert.Equal(t, "collection:gobgg", (&Job{Kind: JobCollection, Username: "GoBGG"}).Key())
+	assert.Equal(t, "plays:gobgg:1", (&Job{Kind: JobPlays, Username: "gobgg"}).Key())
+	assert.Equal(t, "plays:#13:2", (&Job{Kind: JobPlays, IDs: []int64{13}, Page: 2}).Key())
+	assert.Equal(t, "plays:gobgg#13:1", (&Job{Kind: JobPlays, Username: "GoBGG", IDs: []int64{13}}).Key())
+	assert.Equal(t, "user:gobgg", (&Job{Kind: JobUser, Username: "gobgg"}).Key())
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	srv := bggtest.NewServer()
+	defer srv.Close()
+
+	limiter := &countLimiter{}
+	bgg := gobgg.NewBGGClient(append(srv.ClientOptions(), gobgg.SetLimiter(limiter),
+		gobgg.SetRetryPolicy(gobgg.RetryPolicy{MaxRetries: 1, Delay: time.Millisecond}))...)
+
+	// The first thing call and the first collection call fail, they are retried by the client, the
+	// user error is not transient and is not retried
+	srv.ErrorEnvelope(bggtest.PathThing, "Rate limit exceeded", 1)
+	srv.Fail(bggtest.PathCollection, http.StatusTooManyRequests, 1)
+	srv.ErrorEnvelope(bggtest.PathUser, "Invalid username specified", 1)
+
+	var reports []Progress
+	f := New(bgg, Workers(3), CollectionOptions(gobgg.SetCollectionTypes(gobgg.CollectionTypeOwn)),
+		OnProgress(func(p Progress) {
+			reports = append(reports, p)
+		}))
+
+	results := make(map[string]*Result)
+	progress, err := f.Run(ctx, Jobs(
+		Job{Kind: JobThing, IDs: []int64{342942, 174430, 13}},
+		CollectionJob(bggtest.DemoUser),
+		PlaysJob(bggtest.DemoUser, 1),
+		GamePlaysJob(342942, 1),
+		UserJob(bggtest.DemoUser),
+		Job{Kind: JobThing, IDs: []int64{13, 174430, 342942}},
+		Job{Kind: "unknown"},
+	), func(r *Result) error {
+		results[r.Job.Key()] = r
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, progress.Done)
+	assert.Equal(t, 2, progress.Failed)
+	assert.Equal(t, 1, progress.Skipped)
+	require.Len(t, reports, 7)
+	assert.Equal(t, progress.Done, reports[6].Done)
+
+	assert.Len(t, results["thing:13,174430,342942"].Things, 3)
+	assert.Len(t, results["collection:gobgg"].Collection, 3)
+	assert.Len(t, results["plays:gobgg:1"].Plays.Items, 4)
+	assert.NotEmpty(t, results["plays:#342942:1"].Plays.Items)
+	assert.ErrorContains(t, results["user:gobgg"].Err, "Invalid username specified")
+	assert.ErrorIs(t, results["unknown:"].Err, ErrInvalidJob)
+
+	// All the requests (including the retries) went through the client limiter
+	assert.Equal(t, int64(7), limiter.n.Load())
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	srv := bggtest.NewServer()
+	defer srv.Close()
+
+	bgg := gobgg.NewBGGClient(srv.ClientOptions()...)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	jobs := []Job{
+		UserJob(bggtest.DemoUser),
+		GamePlaysJob(13, 1),
+		CollectionJob(bggtest.DemoUser),
+		PlaysJob(bggtest.DemoUser, 1),
+	}
+
+	// The run is interrupted after the second job
+	errStop := errors.New("stop")
+	var fetched []string
+	progress, err := New(bgg, Workers(1), Checkpoint(checkpoint)).Run(ctx, Jobs(jobs...), func(r *Result) error {
+		if len(fetched) == 2 {
+			return errStop
+		}
+		fetched = append(fetched, r.Job.Key())
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	assert.Equal(t, []string{"user:gobgg", "plays:#13:1"}, fetched)
+	assert.Equal(t, 2, progress.Done)
+
+	data, err := os.ReadFile(checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "user:gobgg\nplays:#13:1\n", string(data))
+
+	// Resume, the finished jobs are skipped
+	progress, err = New(bgg, Workers(2), Checkpoint(checkpoint)).Run(ctx, Jobs(jobs...), func(r *Result) error {
+		fetched = append(fetched, r.Job.Key())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Progress{Done: 2, Skipped: 2, Elapsed: progress.Elapsed}, progress)
+	assert.ElementsMatch(t, []string{"user:gobgg", "plays:#13:1", "collection:gobgg", "plays:gobgg:1"}, fetched)
+
+	// Canceled before the start, nothing is fetched
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	progress, err = New(bgg, Checkpoint(checkpoint)).Run(cctx, Jobs(jobs...), nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, progress.Done)
+}
+
+func TestRetryFailed(t *testing.T) {
+	ctx := context.Background()
+	srv := bggtest.NewServer()
+	defer srv.Close()
+
+	bgg := gobgg.NewBGGClient(srv.ClientOptions()...)
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+	require.NoError(t, os.WriteFile(checkpoint, []byte("plays:gobgg:1\n"), 0o644))
+	srv.ErrorEnvelope(bggtest.PathUser, "Invalid username specified", 1)
+
+	// The failed job is repeated after its result, it is fetched again
+	jobs := make(chan Job)
+	failed := make(chan struct{})
+	go func() {
+		defer close(jobs)
+		jobs <- UserJob(bggtest.DemoUser)
+		<-failed
+		jobs <- PlaysJob(bggtest.DemoUser, 1)
+		jobs <- UserJob(bggtest.DemoUser)
+	}()
+
+	var errs []error
+	progress, err := New(bgg, Workers(1), Checkpoint(checkpoint)).Run(ctx, jobs, func(r *Result) error {
+		errs = append(errs, r.Err)
+		if r.Err != nil {
+			close(failed)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, Progress{Done: 1, Failed: 1, Skipped: 1, Elapsed: progress.Elapsed}, progress)
+	require.Len(t, errs, 2)
+	assert.Error(t, errs[0])
+	assert.NoError(t, errs[1])
+
+	data, err := os.ReadFile(checkpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "plays:gobgg:1\nuser:gobgg\n", string(data))
+}
@@ -0,0 +1,349 @@
+package importer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// ErrGameNotFound is returned when the game name can not be resolved to a BGG id
+var ErrGameNotFound = errors.New("game not found")
+
+// ErrAmbiguousGame is returned when the game name resolves to more than one BGG id
+var ErrAmbiguousGame = errors.New("ambiguous game name")
+
+// Failure is a play that could not be imported
+type Failure struct {
+	Play gobgg.Play
+	Err  error
+}
+
+// Result is the import result
+type Result struct {
+	// Posted are the plays that are posted (or would be posted, in dry run mode)
+	Posted []gobgg.Play
+	// Duplicates are the plays that are already in the user plays
+	Duplicates []gobgg.Play
+	// Resumed are the plays that are skipped because they are in the checkpoint file
+	Resumed []gobgg.Play
+	// Failed are the plays that are failed to resolve or post
+	Failed []Failure
+}
+
+// Importer posts the plays into BGG
+type Importer struct {
+	bgg        *gobgg.BGG
+	dryRun     bool
+	dedup      bool
+	checkpoint string
+	games      map[string]int64
+	players    map[string]gobgg.Player
+	locations  map[string]string
+}
+
+// OptionSetter is the option setter for the importer
+type OptionSetter func(*Importer)
+
+// DryRun resolves the games and checks the duplicates, but does not post anything
+func DryRun(dry bool) OptionSetter {
+	return func(im *Importer) {
+		im.dryRun = dry
+	}
+}
+
+// Dedup skips the plays that are already logged for the active user, a play is duplicate when
+// the date, game, quantity and the players are the same. The matches are counted, so two same
+// plays in the input are both posted if there is only one of them in the user plays
+func Dedup(dedup bool) OptionSetter {
+	return func(im *Importer) {
+		im.dedup = dedup
+	}
+}
+
+// Checkpoint sets a file to keep track of the posted plays, so an interrupted import can be
+// resumed by running it again with the same file
+func Checkpoint(path string) OptionSetter {
+	return func(im *Importer) {
+		im.checkpoint = path
+	}
+}
+
+// GameIDs is a manual mapping from the game name (case-insensitive) to the BGG id, it is checked
+// before the search
+func GameIDs(games map[string]int64) OptionSetter {
+	return func(im *Importer) {
+		for name, id := range games {
+			im.games[strings.ToLower(name)] = id
+		}
+	}
+}
+
+// Players maps the external player names (case-insensitive) to the BGG players, for example
+// to add the BGG username to a player
+func Players(players map[string]gobgg.Player) OptionSetter {
+	return func(im *Importer) {
+		for name, p := range players {
+			im.players[strings.ToLower(name)] = p
+		}
+	}
+}
+
+// Locations maps the external location names to the BGG location names
+func Locations(locations map[string]string) OptionSetter {
+	return func(im *Importer) {
+		for from, to := range locations {
+			im.locations[strings.ToLower(from)] = to
+		}
+	}
+}
+
+// New creates a new importer, the client should be logged in (unless it is a dry run)
+func New(bgg *gobgg.BGG, opts ...OptionSetter) *Importer {
+	im := &Importer{
+		bgg:       bgg,
+		games:     make(map[string]int64),
+		players:   make(map[string]gobgg.Player),
+		locations: make(map[string]string),
+	}
+
+	for i := range opts {
+		opts[i](im)
+	}
+
+	return im
+}
+
+func (im *Importer) resolveGame(ctx context.Context, item *gobgg.Item) error {
+	if item.ID > 0 {
+		return nil
+	}
+
+	key := strings.ToLower(strings.TrimSpace(item.Name))
+	if key == "" {
+		return ErrGameNotFound
+	}
+
+	if id, ok := im.games[key]; ok {
+		if id <= 0 {
+			return fmt.Errorf("%w: %q", ErrGameNotFound, item.Name)
+		}
+		item.ID = id
+		return nil
+	}
+
+	result, err := im.bgg.Search(ctx, item.Name, gobgg.SearchExact(),
+		gobgg.SearchTypes(gobgg.BoardGameType, gobgg.BoardGameExpansionType))
+	if err != nil {
+		return fmt.Errorf("search %q failed: %w", item.Name, err)
+	}
+
+	var ids []int64
+	for i := range result {
+		if !slices.Contains(ids, result[i].ID) {
+			ids = append(ids, result[i].ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		im.games[key] = 0
+		return fmt.Errorf("%w: %q", ErrGameNotFound, item.Name)
+	case 1:
+		im.games[key] = ids[0]
+		item.ID = ids[0]
+		return nil
+	}
+
+	return fmt.Errorf("%w: %q matches %v, use GameIDs to set it", ErrAmbiguousGame, item.Name, ids)
+}
+
+func (im *Importer) mapPlay(play *gobgg.Play) {
+	play.Item.Type = "thing"
+	if loc, ok := im.locations[strings.ToLower(play.Location)]; ok {
+		play.Location = loc
+	}
+
+	for i := range play.Players {
+		mapped, ok := im.players[strings.ToLower(play.Players[i].Name)]
+		if !ok {
+			continue
+		}
+		if mapped.Name != "" {
+			play.Players[i].Name = mapped.Name
+		}
+		if mapped.UserName != "" {
+			play.Players[i].UserName = mapped.UserName
+		}
+		if mapped.UserID != 0 {
+			play.Players[i].UserID = mapped.UserID
+		}
+	}
+}
+
+// Fingerprint is the key used for deduplication and the checkpoint file, it is based on the date,
+// game id, quantity and the player names. The same plays have the same fingerprint, the
+// checkpoint adds the occurrence index to it
+func Fingerprint(play *gobgg.Play) string {
+	names := make([]string, 0, len(play.Players))
+	for i := range play.Players {
+		name := play.Players[i].UserName
+		if name == "" {
+			name = play.Players[i].Name
+		}
+		names = append(names, strings.ToLower(name))
+	}
+	slices.Sort(names)
+
+	return fmt.Sprintf("%s|%d|%g|%s", play.Date.Format("2006-01-02"), play.Item.ID, play.Quantity,
+		strings.Join(names, ","))
+}
+
+// existingPlays returns the number of the user plays for each fingerprint
+func (im *Importer) existingPlays(ctx context.Context, plays []gobgg.Play) (map[string]int, error) {
+	username := im.bgg.GetActiveUsername()
+	if username == "" {
+		return nil, errors.New("dedup requires the active username, call Login first")
+	}
+
+	var minDate, maxDate time.Time
+	for i := range plays {
+		if minDate.IsZero() || plays[i].Date.Before(minDate) {
+			minDate = plays[i].Date
+		}
+		if plays[i].Date.After(maxDate) {
+			maxDate = plays[i].Date
+		}
+	}
+
+	result := make(map[string]int)
+	for page := 1; ; page++ {
+		p, err := im.bgg.Plays(ctx, gobgg.SetUserName(username), gobgg.SetPageNumber(page),
+			gobgg.SetDateRangeMin(minDate), gobgg.SetDateRangeMax(maxDate))
+		if err != nil {
+			return nil, fmt.Errorf("get plays failed: %w", err)
+		}
+
+		if len(p.Items) == 0 {
+			break
+		}
+
+		for i := range p.Items {
+			result[Fingerprint(&p.Items[i])]++
+		}
+	}
+
+	return result, nil
+}
+
+func readCheckpoint(path string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open checkpoint failed: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			result[line] = true
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// Import resolves and posts the plays, the failures on a single play do not stop the import
+// and are reported in the result. The returned error is only for the fatal errors
+func (im *Importer) Import(ctx context.Context, plays []gobgg.Play) (*Result, error) {
+	result := &Result{}
+
+	var (
+		done = make(map[string]bool)
+		cp   *os.File
+		err  error
+	)
+	if im.checkpoint != "" {
+		if done, err = readCheckpoint(im.checkpoint); err != nil {
+			return nil, err
+		}
+
+		if !im.dryRun {
+			cp, err = os.OpenFile(im.checkpoint, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+			if err != nil {
+				return nil, fmt.Errorf("open checkpoint failed: %w", err)
+			}
+			defer cp.Close()
+		}
+	}
+
+	resolved := make([]gobgg.Play, 0, len(plays))
+	for i := range plays {
+		play := plays[i]
+		play.Players = slices.Clone(play.Players)
+		if err := im.resolveGame(ctx, &play.Item); err != nil {
+			result.Failed = append(result.Failed, Failure{Play: play, Err: err})
+			continue
+		}
+		im.mapPlay(&play)
+		resolved = append(resolved, play)
+	}
+
+	existing := make(map[string]int)
+	if im.dedup && len(resolved) > 0 {
+		if existing, err = im.existingPlays(ctx, resolved); err != nil {
+			return nil, err
+		}
+	}
+
+	// The same plays are told apart by their occurrence in the input, the first ones are matched
+	// with the existing plays
+	occurrences := make(map[string]int)
+	for i := range resolved {
+		play := resolved[i]
+		fp := Fingerprint(&play)
+		occurrences[fp]++
+		key := fmt.Sprintf("%s#%d", fp, occurrences[fp])
+		if done[key] {
+			result.Resumed = append(result.Resumed, play)
+			continue
+		}
+
+		if occurrences[fp] <= existing[fp] {
+			result.Duplicates = append(result.Duplicates, play)
+			continue
+		}
+
+		if im.dryRun {
+			result.Posted = append(result.Posted, play)
+			continue
+		}
+
+		if _, err := im.bgg.PostPlay(ctx, &play); err != nil {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			result.Failed = append(result.Failed, Failure{Play: play, Err: err})
+			continue
+		}
+
+		result.Posted = append(result.Posted, play)
+		if cp != nil {
+			if _, err := fmt.Fprintln(cp, key); err != nil {
+				return result, fmt.Errorf("write checkpoint failed: %w", err)
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,184 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/export"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const bgStatsBackup = `{
+	"userInfo": {"meRefId": 1},
+	"games": [
+		{"id": 1, "name": "Ark Nova", "bggId": 0},
+		{"id": 2, "name": "Hokm", "bggId": 23383, "bggName": "Hokm"},
+		{"id": 3, "name": "Homebrew", "bggId": 0}
+	],
+	"players": [
+		{"id": 1, "name": "Forud", "bggUsername": "fzerorubigd"},
+		{"id": 2, "name": "Friend"}
+	],
+	"locations": [{"id": 1, "name": "Home"}],
+	"plays": [
+		{"gameRefId": 1, "locationRefId": 1, "playDate": "2024-01-02 20:00:00", "durationMin": 120,
+		 "playerScores": [{"playerRefId": 1, "score": "120.5", "winner": true, "seatOrder": 1}, {"playerRefId": 2, "score": "99"}]},
+		{"gameRefId": 2, "locationRefId": 1, "playDate": "2024-01-03 20:00:00", "durationMin": 30,
+		 "playerScores": [{"playerRefId": 1, "score": "7"}, {"playerRefId": 2, "score": "5"}]},
+		{"gameRefId": 2, "locationRefId": 1, "playDate": "2024-01-03 21:00:00", "durationMin": 30,
+		 "playerScores": [{"playerRefId": 1, "score": "3"}, {"playerRefId": 2, "score": "7"}]},
+		{"gameRefId": 3, "playDate": "2024-01-04 20:00:00", "durationMin": 30, "playerScores": []},
+		{"gameRefId": 1, "playDate": "2024-01-05 20:00:00", "durationMin": 10, "ignored": true, "playerScores": []}
+	]
+}`
+
+const existingPlays = `<?xml version="1.0" encoding="utf-8"?>
+<plays username="fzerorubigd" userid="1" total="1" page="%s" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<play id="1" date="2024-01-03" quantity="1" length="30" incomplete="0" nowinstats="1" location="Home">
+		<item name="Hokm" objecttype="thing" objectid="23383"><subtypes><subtype value="boardgame" /></subtypes></item>
+		<players>
+			<player username="fzerorubigd" userid="1" name="Forud" startposition="" color="" score="7" new="0" rating="0" win="0" />
+			<player username="" userid="0" name="Friend" startposition="" color="" score="5" new="0" rating="0" win="0" />
+		</players>
+	</play>
+</plays>`
+
+type fakeBGG struct {
+	posted   []map[string]any
+	searches int
+}
+
+func (f *fakeBGG) register() {
+	httpmock.RegisterResponder(http.MethodGet, "https://boardgamegeek.com/xmlapi2/search",
+		func(req *http.Request) (*http.Response, error) {
+			f.searches++
+			body := `<?xml version="1.0" encoding="utf-8"?><items total="0"></items>`
+			if req.URL.Query().Get("query") == "Ark Nova" {
+				body = `<?xml version="1.0" encoding="utf-8"?><items total="2">
+				<item type="boardgame" id="342942"><name type="primary" value="Ark Nova"/></item>
+				<item type="boardgameexpansion" id="342942"><name type="primary" value="Ark Nova"/></item>
+				</items>`
+			}
+			return httpmock.NewStringResponse(http.StatusOK, body), nil
+		})
+
+	httpmock.RegisterResponder(http.MethodGet, "https://boardgamegeek.com/xmlapi2/plays",
+		func(req *http.Request) (*http.Response, error) {
+			page := req.URL.Query().Get("page")
+			if page != "1" {
+				return httpmock.NewStringResponse(http.StatusOK, `<?xml version="1.0" encoding="utf-8"?><plays></plays>`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, strings.Replace(existingPlays, "%s", page, 1)), nil
+		})
+
+	httpmock.RegisterResponder(http.MethodPost, "https://boardgamegeek.com/geekplay.php",
+		func(req *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(req.Body)
+			payload := map[string]any{}
+			_ = json.Unmarshal(data, &payload)
+			f.posted = append(f.posted, payload)
+			return httpmock.NewStringResponse(http.StatusOK, `{"playid":"10","numplays":1}`), nil
+		})
+}
+
+func newClient() *gobgg.BGG {
+	return gobgg.NewBGGClient(gobgg.SetCookies("fzerorubigd", []*http.Cookie{{Name: "session", Value: "x"}}))
+}
+
+func TestFromBGStats(t *testing.T) {
+	plays, err := FromBGStats(strings.NewReader(bgStatsBackup))
+	require.NoError(t, err)
+	// The ignored play is not imported
+	require.Len(t, plays, 4)
+
+	assert.Equal(t, "Ark Nova", plays[0].Item.Name)
+	assert.Zero(t, plays[0].Item.ID)
+	assert.Equal(t, "Home", plays[0].Location)
+	assert.Equal(t, 120*time.Minute, plays[0].Length)
+	require.Len(t, plays[0].Players, 2)
+	assert.Equal(t, "fzerorubigd", plays[0].Players[0].UserName)
+	assert.Equal(t, 1, plays[0].Players[0].StartPosition)
+	assert.Equal(t, 120.5, plays[0].Players[0].Score)
+	assert.True(t, plays[0].Players[0].Win)
+	assert.Equal(t, int64(23383), plays[1].Item.ID)
+}
+
+func TestFromCSV(t *testing.T) {
+	plays, err := FromBGStats(strings.NewReader(bgStatsBackup))
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, export.PlaysCSV(buf, plays))
+	fromCSV, err := FromCSV(buf)
+	require.NoError(t, err)
+	require.Len(t, fromCSV, len(plays))
+	for i := range plays {
+		assert.Equal(t, plays[i].Item, fromCSV[i].Item)
+		assert.Equal(t, plays[i].Length, fromCSV[i].Length)
+		assert.Equal(t, plays[i].Players, fromCSV[i].Players)
+	}
+
+	_, err = FromCSV(strings.NewReader("name,score\nfoo,1\n"))
+	require.Error(t, err)
+}
+
+func TestImport(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	fake := &fakeBGG{}
+	fake.register()
+
+	plays, err := FromBGStats(strings.NewReader(bgStatsBackup))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint")
+
+	// Dry run, nothing is posted and the checkpoint is not written
+	result, err := New(newClient(), DryRun(true), Dedup(true), Checkpoint(checkpoint)).Import(ctx, plays)
+	require.NoError(t, err)
+	// Hokm is played twice with the same group, only the first one is already logged
+	require.Len(t, result.Posted, 2)
+	assert.Equal(t, int64(342942), result.Posted[0].Item.ID)
+	assert.Equal(t, int64(23383), result.Posted[1].Item.ID)
+	require.Len(t, result.Duplicates, 1)
+	assert.Equal(t, int64(23383), result.Duplicates[0].Item.ID)
+	require.Len(t, result.Failed, 1)
+	assert.True(t, errors.Is(result.Failed[0].Err, ErrGameNotFound))
+	assert.Empty(t, fake.posted)
+	assert.Equal(t, 2, fake.searches)
+	_, err = os.Stat(checkpoint)
+	assert.True(t, os.IsNotExist(err))
+
+	// Real run, with a manual game id for the homebrew game
+	im := New(newClient(), Dedup(true), Checkpoint(checkpoint), GameIDs(map[string]int64{"homebrew": 1}),
+		Players(map[string]gobgg.Player{"friend": {UserName: "friend_bgg"}}))
+	result, err = im.Import(ctx, plays)
+	require.NoError(t, err)
+	require.Len(t, result.Posted, 4)
+	require.Len(t, fake.posted, 4)
+	assert.Equal(t, "342942", fake.posted[0]["objectid"])
+	assert.Equal(t, "thing", fake.posted[0]["objecttype"])
+	// The player mapping changed the fingerprint, so Hokm is not a duplicate anymore
+	assert.Equal(t, "friend_bgg", result.Posted[1].Players[1].UserName)
+
+	// Resume, everything is in the checkpoint
+	result, err = New(newClient(), Checkpoint(checkpoint), GameIDs(map[string]int64{"homebrew": 1}),
+		Players(map[string]gobgg.Player{"friend": {UserName: "friend_bgg"}})).Import(ctx, plays)
+	require.NoError(t, err)
+	assert.Len(t, result.Resumed, 4)
+	assert.Empty(t, result.Posted)
+	assert.Len(t, fake.posted, 4)
+}
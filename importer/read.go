@@ -0,0 +1,221 @@
+// Package importer reads plays from other loggers (BG Stats backup, play log CSV) and posts them
+// into BGG, it supports dry run, deduplication against the existing plays and resuming
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/fzerorubigd/gobgg/export"
+)
+
+// FromBGStats reads the BG Stats backup JSON and converts the plays into BGG plays, games
+// without a BGG id have only the name and are resolved later by the importer
+func FromBGStats(r io.Reader) ([]gobgg.Play, error) {
+	var backup export.BGStats
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("decoding JSON failed: %w", err)
+	}
+
+	games := make(map[int64]*export.BGStatsGame, len(backup.Games))
+	for i := range backup.Games {
+		games[backup.Games[i].ID] = &backup.Games[i]
+	}
+	players := make(map[int64]*export.BGStatsPlayer, len(backup.Players))
+	for i := range backup.Players {
+		players[backup.Players[i].ID] = &backup.Players[i]
+	}
+	locations := make(map[int64]string, len(backup.Locations))
+	for i := range backup.Locations {
+		locations[backup.Locations[i].ID] = backup.Locations[i].Name
+	}
+
+	result := make([]gobgg.Play, 0, len(backup.Plays))
+	for i := range backup.Plays {
+		bp := &backup.Plays[i]
+		if bp.Ignored {
+			// The play is excluded in BG Stats
+			continue
+		}
+		game, ok := games[bp.GameRefID]
+		if !ok {
+			return nil, fmt.Errorf("play %d: game %d not found", i, bp.GameRefID)
+		}
+
+		date, err := time.ParseInLocation(export.BGStatsTimeFormat, bp.PlayDate, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("play %d: invalid date %q: %w", i, bp.PlayDate, err)
+		}
+
+		name := game.BGGName
+		if name == "" {
+			name = game.Name
+		}
+		play := gobgg.Play{
+			Date:       date,
+			Quantity:   1,
+			Length:     time.Duration(bp.DurationMin) * time.Minute,
+			Incomplete: bp.Incomplete,
+			Location:   locations[bp.LocationRefID],
+			Comment:    bp.Comments,
+			Item: gobgg.Item{
+				ID:   game.BGGID,
+				Name: name,
+			},
+		}
+
+		for _, ps := range bp.PlayerScores {
+			player, ok := players[ps.PlayerRefID]
+			if !ok {
+				return nil, fmt.Errorf("play %d: player %d not found", i, ps.PlayerRefID)
+			}
+
+			start := ""
+			if ps.SeatOrder > 0 {
+				start = strconv.Itoa(ps.SeatOrder)
+			}
+			play.Players = append(play.Players, gobgg.Player{
+				UserName:          player.BGGUsername,
+				Name:              player.Name,
+				StartPosition:     ps.SeatOrder,
+				StartPositionText: start,
+				Color:             ps.Role,
+				Score:             safeFloat(ps.Score),
+				ScoreText:         ps.Score,
+				NoScore:           strings.TrimSpace(ps.Score) == "",
+				New:               ps.NewPlayer,
+				Win:               ps.Winner,
+			})
+		}
+
+		result = append(result, play)
+	}
+
+	return result, nil
+}
+
+// FromCSV reads the play log CSV (the same layout as export.PlaysCSV), the columns are matched
+// by the header name so other apps exports with the same column names are also accepted.
+// Only the date and one of the objectid or objectname columns are required
+func FromCSV(r io.Reader) ([]gobgg.Play, error) {
+	rcsv := csv.NewReader(r)
+	rcsv.FieldsPerRecord = -1
+	header, err := rcsv.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header failed: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i := range header {
+		columns[strings.ToLower(strings.TrimSpace(header[i]))] = i
+	}
+
+	_, hasID := columns["objectid"]
+	_, hasName := columns["objectname"]
+	if _, ok := columns["date"]; !ok || (!hasID && !hasName) {
+		return nil, errors.New("the date and objectid or objectname columns are required")
+	}
+
+	var result []gobgg.Play
+	for line := 2; ; line++ {
+		record, err := rcsv.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read line %d failed: %w", line, err)
+		}
+
+		get := func(name string) string {
+			idx, ok := columns[name]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", get("date"), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q: %w", line, get("date"), err)
+		}
+
+		quantity, _ := strconv.ParseFloat(get("quantity"), 64)
+		if quantity <= 0 {
+			quantity = 1
+		}
+		length, _ := strconv.Atoi(get("length"))
+		id, _ := strconv.ParseInt(get("objectid"), 10, 64)
+
+		play := gobgg.Play{
+			Date:       date,
+			Quantity:   quantity,
+			Length:     time.Duration(length) * time.Minute,
+			Incomplete: get("incomplete") == "1",
+			Location:   get("location"),
+			Comment:    get("comments"),
+			Item: gobgg.Item{
+				ID:   id,
+				Name: get("objectname"),
+			},
+			Players: parsePlayers(get("players")),
+		}
+
+		result = append(result, play)
+	}
+
+	return result, nil
+}
+
+func parsePlayers(in string) []gobgg.Player {
+	if in == "" {
+		return nil
+	}
+
+	var result []gobgg.Player
+	for _, p := range strings.Split(in, ";") {
+		fields := strings.Split(p, "|")
+		get := func(name string) string {
+			for i := range export.PlayerFields {
+				if export.PlayerFields[i] == name && i < len(fields) {
+					return fields[i]
+				}
+			}
+			return ""
+		}
+
+		userID, _ := strconv.ParseInt(get("userid"), 10, 64)
+		start, _ := strconv.Atoi(get("startposition"))
+		result = append(result, gobgg.Player{
+			UserName:          get("username"),
+			UserID:            userID,
+			Name:              get("name"),
+			StartPosition:     start,
+			StartPositionText: get("startposition"),
+			Color:             get("color"),
+			Score:             safeFloat(get("score")),
+			ScoreText:         get("score"),
+			NoScore:           strings.TrimSpace(get("score")) == "",
+			New:               get("new") == "1",
+			Rating:            safeFloat(get("rating")),
+			Win:               get("win") == "1",
+		})
+	}
+
+	return result
+}
+
+func safeFloat(in string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(in), 64)
+	if err != nil {
+		return 0
+	}
+
+	return f
+}
@@ -7,8 +7,6 @@ import (
 	"io"
 	"net/http"
 	"time"
-
-	"golang.org/x/net/html"
 )
 
 const (
@@ -147,59 +145,146 @@ type IDDelta struct {
 	Delta int
 }
 
+// GeekSite is the site in the geekdo network
+type GeekSite string
+
+const (
+	// GeekSiteBoardGame is the boardgamegeek
+	GeekSiteBoardGame GeekSite = "boardgame"
+	// GeekSiteRPG is the rpggeek
+	GeekSiteRPG GeekSite = "rpg"
+	// GeekSiteVideoGame is the videogamegeek
+	GeekSiteVideoGame GeekSite = "videogame"
+)
+
+// HotnessObjectType is the object type for the hotness api
+type HotnessObjectType string
+
+const (
+	// HotnessThing is for games (and rpg items, video games)
+	HotnessThing HotnessObjectType = "thing"
+	// HotnessPerson is for designers, artists, ...
+	HotnessPerson HotnessObjectType = "person"
+	// HotnessCompany is for publishers
+	HotnessCompany HotnessObjectType = "company"
+)
+
+// HotItem is a single item in the hotness list
+type HotItem struct {
+	ID            int64             `json:"id"`
+	Rank          int               `json:"rank"`
+	Delta         int               `json:"delta"`
+	Name          string            `json:"name"`
+	ObjectType    string            `json:"object_type"`
+	Type          string            `json:"type"`
+	YearPublished int               `json:"year_published,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	ImageURL      string            `json:"image_url,omitempty"`
+	Images        map[string]string `json:"images,omitempty"`
+	Href          string            `json:"href"`
+}
+
+// HotnessOption is the option for the hotness api
+type HotnessOption struct {
+	geekSite   GeekSite
+	objectType HotnessObjectType
+}
+
+// HotnessOptionSetter is the option setter for the hotness api
+type HotnessOptionSetter func(*HotnessOption)
+
+// HotnessGeekSite sets the geek site, default is board game
+func HotnessGeekSite(site GeekSite) HotnessOptionSetter {
+	return func(opt *HotnessOption) {
+		opt.geekSite = site
+	}
+}
+
+// HotnessType sets the object type, default is thing
+func HotnessType(typ HotnessObjectType) HotnessOptionSetter {
+	return func(opt *HotnessOption) {
+		opt.objectType = typ
+	}
+}
+
+// TrendImage is a single image in the trends result
+type TrendImage struct {
+	Src   string `json:"src"`
+	Src2X string `json:"src_2x"`
+}
+
+// TrendDescriptor is a descriptor of the trend item, like the year published
+type TrendDescriptor struct {
+	Name         string `json:"name"`
+	DisplayValue string `json:"display_value"`
+}
+
 type TrendOutput struct {
-	ID          int64
-	Delta       int
-	Appearances int
+	ID          int64 `json:"id"`
+	Delta       int   `json:"delta"`
+	Appearances int   `json:"appearances"`
+
+	Rank        int               `json:"rank"`
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Href        string            `json:"href"`
+	Description string            `json:"description,omitempty"`
+	Descriptors []TrendDescriptor `json:"descriptors,omitempty"`
+	Images      struct {
+		Square100    TrendImage `json:"square_100"`
+		Mediacard100 TrendImage `json:"mediacard_100"`
+		Mediacard    TrendImage `json:"mediacard"`
+	} `json:"images"`
 }
 
+// TopPages returns the board game ids in the rank order, each page has 100 items. Use Rankings for
+// the full row data
 func (bgg *BGG) TopPages(ctx context.Context, page int) ([]int64, error) {
-	u := bgg.buildURL(fmt.Sprintf(topPages, page), map[string]string{})
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	items, err := bgg.Rankings(ctx, RankingsPage(page))
 	if err != nil {
-		return nil, fmt.Errorf("create request failed: %w", err)
+		return nil, err
 	}
-	resp, err := bgg.do(req)
-	if err != nil {
-		return nil, fmt.Errorf("get request failed: %w", err)
+
+	result := make([]int64, len(items))
+	for i := range items {
+		result[i] = items[i].ID
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
 
-	doc, err := html.Parse(resp.Body)
+	return result, nil
+}
+
+// Hotness returns the hot board games and their change since yesterday
+func (bgg *BGG) Hotness(ctx context.Context, count int) ([]IDDelta, error) {
+	items, err := bgg.HotItems(ctx, count)
 	if err != nil {
-		return nil, fmt.Errorf("parse html failed: %w", err)
+		return nil, err
 	}
 
-	var result []int64
-	var crawler func(*html.Node)
-	crawler = func(node *html.Node) {
-		if node.Type == html.ElementNode && node.Data == "a" {
-			if getAttr(node.Attr, "class") == "primary" {
-				id := getID(getAttr(node.Attr, "href"), "boardgame")
-				if id > 0 {
-					result = append(result, id)
-				}
-			}
-			return
-		}
-		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			crawler(child)
-		}
+	final := make([]IDDelta, len(items))
+	for i := range items {
+		final[i].ID = items[i].ID
+		final[i].Delta = items[i].Delta
 	}
-	crawler(doc)
 
-	return result, nil
+	return final, nil
 }
 
-func (bgg *BGG) Hotness(ctx context.Context, count int) ([]IDDelta, error) {
+// HotItems returns the full hotness data, it supports the other geek sites and object types
+func (bgg *BGG) HotItems(ctx context.Context, count int, setters ...HotnessOptionSetter) ([]HotItem, error) {
+	opt := HotnessOption{
+		geekSite:   GeekSiteBoardGame,
+		objectType: HotnessThing,
+	}
+	for i := range setters {
+		setters[i](&opt)
+	}
+
 	if count < 1 || count > 50 {
 		count = 50
 	}
 	u := bgg.buildURL(hotnessPage, map[string]string{
-		"geeksite":   "boardgame",
-		"objecttype": "thing",
+		"geeksite":   string(opt.geekSite),
+		"objecttype": string(opt.objectType),
 		"showcount":  fmt.Sprint(count),
 	})
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
@@ -224,15 +309,51 @@ func (bgg *BGG) Hotness(ctx context.Context, count int) ([]IDDelta, error) {
 		return nil, fmt.Errorf("JSON parsing failed: %w", err)
 	}
 
-	final := make([]IDDelta, len(result.Items))
+	final := make([]HotItem, len(result.Items))
 	for i := range result.Items {
-		final[i].ID = safeInt(result.Items[i].ID)
-		final[i].Delta = result.Items[i].Delta
+		item := &result.Items[i]
+		id := item.ID
+		if id == "" {
+			id = item.Objectid
+		}
+		final[i] = HotItem{
+			ID:            safeInt(id),
+			Rank:          int(safeInt(item.Rank)),
+			Delta:         item.Delta,
+			Name:          item.Name,
+			ObjectType:    item.Objecttype,
+			Type:          item.Type,
+			YearPublished: int(safeInt(item.Yearpublished)),
+			Description:   item.Description,
+			ImageURL:      item.Imageurl,
+			Images:        imagesMap(item.Images),
+			Href:          item.Href,
+		}
+		if final[i].Rank == 0 {
+			// The list is sorted by rank
+			final[i].Rank = i + 1
+		}
 	}
 
 	return final, nil
 }
 
+func imagesMap(in any) map[string]string {
+	m, ok := in.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	result := make(map[string]string, len(m))
+	for key, val := range m {
+		if str, ok := val.(string); ok {
+			result[key] = str
+		}
+	}
+
+	return result
+}
+
 func (bgg *BGG) trends(ctx context.Context, u string) ([]TrendOutput, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
@@ -258,9 +379,23 @@ func (bgg *BGG) trends(ctx context.Context, u string) ([]TrendOutput, error) {
 
 	final := make([]TrendOutput, len(result.Items))
 	for i := range result.Items {
-		final[i].ID = safeInt(result.Items[i].Item.ID)
-		final[i].Delta = result.Items[i].Delta
-		final[i].Appearances = result.Items[i].Appearances
+		item := &result.Items[i]
+		final[i] = TrendOutput{
+			ID:          safeInt(item.Item.ID),
+			Delta:       item.Delta,
+			Appearances: item.Appearances,
+			Rank:        item.Rank,
+			Name:        item.Item.Name,
+			Type:        item.Item.Type,
+			Href:        item.Item.Href,
+			Description: item.Description,
+		}
+		final[i].Images.Square100 = TrendImage(item.Item.ImageSets.Square100)
+		final[i].Images.Mediacard100 = TrendImage(item.Item.ImageSets.Mediacard100)
+		final[i].Images.Mediacard = TrendImage(item.Item.ImageSets.Mediacard)
+		for _, d := range item.Item.Descriptors {
+			final[i].Descriptors = append(final[i].Descriptors, TrendDescriptor(d))
+		}
 	}
 
 	return final, nil
@@ -0,0 +1,153 @@
+package gobgg
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotItems(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", hotnessPage,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			if q.Get("geeksite") == "rpg" {
+				assert.Equal(t, "person", q.Get("objecttype"))
+				return httpmock.NewStringResponse(200, `{"items":[{"objecttype":"person","objectid":"12","id":"12","name":"Designer","delta":0}]}`), nil
+			}
+			assert.Equal(t, "boardgame", q.Get("geeksite"))
+			assert.Equal(t, "thing", q.Get("objecttype"))
+			return httpmock.NewStringResponse(200, `{"items":[
+				{"objecttype":"thing","objectid":"342942","rep_imageid":"6293412","delta":2,"href":"/boardgame/342942/ark-nova",
+				 "name":"Ark Nova","id":"342942","type":"things","imageurl":"https://cf.geekdo-images.com/ark.jpg",
+				 "images":{"thumb":"https://cf.geekdo-images.com/thumb.jpg","micro":"https://cf.geekdo-images.com/micro.jpg"},
+				 "yearpublished":"2021","rank":"1","description":"Plan and build a modern zoo"},
+				{"objecttype":"thing","objectid":"13","id":"13","name":"CATAN","delta":-1,"images":[]}
+			]}`), nil
+		})
+
+	bgg := NewBGGClient()
+	items, err := bgg.HotItems(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, HotItem{
+		ID:            342942,
+		Rank:          1,
+		Delta:         2,
+		Name:          "Ark Nova",
+		ObjectType:    "thing",
+		Type:          "things",
+		YearPublished: 2021,
+		Description:   "Plan and build a modern zoo",
+		ImageURL:      "https://cf.geekdo-images.com/ark.jpg",
+		Images: map[string]string{
+			"thumb": "https://cf.geekdo-images.com/thumb.jpg",
+			"micro": "https://cf.geekdo-images.com/micro.jpg",
+		},
+		Href: "/boardgame/342942/ark-nova",
+	}, items[0])
+	assert.Equal(t, 2, items[1].Rank)
+	assert.Nil(t, items[1].Images)
+
+	ids, err := bgg.Hotness(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, []IDDelta{{ID: 342942, Delta: 2}, {ID: 13, Delta: -1}}, ids)
+
+	items, err = bgg.HotItems(context.Background(), 10, HotnessGeekSite(GeekSiteRPG), HotnessType(HotnessPerson))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Designer", items[0].Name)
+}
+
+func TestTrends(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", trendMostPlays,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2024-01-01", req.URL.Query().Get("startDate"))
+			return httpmock.NewStringResponse(200, `{"items":[{"id":"1","item":{"type":"things","id":"224517","name":"Brass: Birmingham",
+				"href":"/boardgame/224517/brass-birmingham","descriptors":[{"name":"yearpublished","displayValue":"2018"}],
+				"imageSets":{"square100":{"src":"s.jpg","src@2x":"s2.jpg"},"mediacard":{"src":"m.jpg","src@2x":"m2.jpg"}}},
+				"rank":3,"description":"desc","delta":5,"appearances":12}],"interval":"week","endDate":"2024-01-08T00:00:00+00:00"}`), nil
+		})
+
+	bgg := NewBGGClient()
+	items, err := bgg.MostPlays(context.Background(), TrendIntervalWeek, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	item := items[0]
+	assert.Equal(t, int64(224517), item.ID)
+	assert.Equal(t, 5, item.Delta)
+	assert.Equal(t, 12, item.Appearances)
+	assert.Equal(t, 3, item.Rank)
+	assert.Equal(t, "Brass: Birmingham", item.Name)
+	assert.Equal(t, "desc", item.Description)
+	assert.Equal(t, []TrendDescriptor{{Name: "yearpublished", DisplayValue: "2018"}}, item.Descriptors)
+	assert.Equal(t, TrendImage{Src: "s.jpg", Src2X: "s2.jpg"}, item.Images.Square100)
+	assert.Equal(t, TrendImage{Src: "m.jpg", Src2X: "m2.jpg"}, item.Images.Mediacard)
+
+	// The output uses snake_case like the other output structs
+	b, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"id":224517,"delta":5,"appearances":12,"rank":3`)
+	assert.Contains(t, string(b), `"display_value":"2018"`)
+	assert.Contains(t, string(b), `"square_100":{"src":"s.jpg","src_2x":"s2.jpg"}`)
+}
+
+func TestGetPreviousDay(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		assert.Equal(t, monday, getPreviousDay(monday.AddDate(0, 0, i), time.Monday), i)
+	}
+	// Sunday is the first weekday in Go, but it is the end of the week that starts on Monday
+	sunday := time.Date(2024, 1, 21, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, getPreviousDay(sunday, time.Monday))
+	assert.Equal(t, sunday, getPreviousDay(sunday, time.Sunday))
+	assert.Equal(t, time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC), getPreviousDay(sunday, time.Saturday))
+}
+
+func TestTrendsHistory(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var dates []string
+	httpmock.RegisterResponder("GET", trendMostPlays,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "week", req.URL.Query().Get("interval"))
+			date := req.URL.Query().Get("startDate")
+			dates = append(dates, date)
+			body := `{"items":[{"item":{"id":"1","name":"One"},"rank":2,"delta":1,"appearances":10}`
+			if date == "2024-01-08" {
+				body += `,{"item":{"id":"2","name":"Two"},"rank":1,"delta":5,"appearances":20}`
+			}
+			return httpmock.NewStringResponse(200, body+`]}`), nil
+		})
+
+	bgg := NewBGGClient()
+	series, err := bgg.TrendsHistory(context.Background(), TrendListMostPlays, TrendIntervalWeek,
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024-01-15", "2024-01-08", "2024-01-01"}, dates)
+	require.Len(t, series, 2)
+
+	assert.Equal(t, int64(2), series[0].ID)
+	assert.Equal(t, []TrendPoint{{Start: time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), Rank: 1, Delta: 5, Appearances: 20}}, series[0].Points)
+
+	assert.Equal(t, "One", series[1].Name)
+	require.Len(t, series[1].Points, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), series[1].Points[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), series[1].Points[2].Start)
+
+	_, err = bgg.TrendsHistory(context.Background(), TrendListBestSellers, TrendIntervalMonth,
+		time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+}
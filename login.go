@@ -38,7 +38,7 @@ func (bgg *BGG) Login(ctx context.Context, username, password string) error {
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 && resp.StatusCode >= 300 {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return fmt.Errorf("maybe, invalid username/password")
 	}
 
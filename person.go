@@ -48,7 +48,7 @@ func (bgg *BGG) PersonImage(ctx context.Context, id int64) (*PersonImage, error)
 	defer resp.Body.Close()
 
 	var pr personItem
-	if err = decode(resp.Body, &pr); err != nil {
+	if err = bgg.decode(EndpointPerson, resp.Body, &pr); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 
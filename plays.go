@@ -5,13 +5,20 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 )
 
 const (
 	playsPath     = "xmlapi2/plays"
 	bggTimeFormat = "2006-01-02"
+	// playsPageSize is the number of plays in each page of the plays api
+	playsPageSize = 100
+	// bggDateTimeFormat is used in the collection last modified field
+	bggDateTimeFormat = "2006-01-02 15:04:05"
 )
 
 // playsResponse is the response for the plays
@@ -61,13 +68,30 @@ type playsResponse struct {
 	} `xml:"play"`
 }
 
+// PlaysObjectType is the type of the id in the plays api
+type PlaysObjectType string
+
+const (
+	// PlaysTypeThing is for the game id
+	PlaysTypeThing PlaysObjectType = "thing"
+	// PlaysTypeFamily is for the family id
+	PlaysTypeFamily PlaysObjectType = "family"
+)
+
 // PlaysOption is used to handle func option ins plays api
 type PlaysOption struct {
 	userName         string
 	gameID           int
+	objectType       PlaysObjectType
+	subType          ItemType
 	minDate, maxDate time.Time
 
 	page int
+
+	// client side filters, only used in the PlaysIter
+	onlyComplete bool
+	player       string
+	location     string
 }
 
 // SetUserName for the plays
@@ -84,6 +108,73 @@ func SetGameID(id int) PlaysOptionSetter {
 	}
 }
 
+// SetPlaysType sets the type of the id, thing or family
+func SetPlaysType(typ PlaysObjectType) PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.objectType = typ
+	}
+}
+
+// SetFamilyID gets the plays of all the games in a family
+func SetFamilyID(id int) PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.gameID = id
+		opt.objectType = PlaysTypeFamily
+	}
+}
+
+// SetPlaysSubType limits the plays to a subtype, like boardgame or boardgameexpansion
+func SetPlaysSubType(subType ItemType) PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.subType = subType
+	}
+}
+
+// FilterComplete is a client side filter to skip the incomplete plays, only used in PlaysIter
+func FilterComplete() PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.onlyComplete = true
+	}
+}
+
+// FilterPlayer is a client side filter to return the plays with a player, the name is matched
+// case-insensitive with the player name or username, only used in PlaysIter
+func FilterPlayer(name string) PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.player = name
+	}
+}
+
+// FilterLocation is a client side filter to return the plays in a location (case-insensitive),
+// only used in PlaysIter
+func FilterLocation(location string) PlaysOptionSetter {
+	return func(opt *PlaysOption) {
+		opt.location = location
+	}
+}
+
+func (opt *PlaysOption) match(play *Play) bool {
+	if opt.onlyComplete && play.Incomplete {
+		return false
+	}
+
+	if opt.location != "" && !strings.EqualFold(strings.TrimSpace(play.Location), strings.TrimSpace(opt.location)) {
+		return false
+	}
+
+	if opt.player == "" {
+		return true
+	}
+
+	for i := range play.Players {
+		if strings.EqualFold(play.Players[i].Name, opt.player) || strings.EqualFold(play.Players[i].UserName, opt.player) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // SetPageNumber set the current page
 func SetPageNumber(page int) PlaysOptionSetter {
 	return func(opt *PlaysOption) {
@@ -108,7 +199,8 @@ func SetDateRangeMax(max time.Time) PlaysOptionSetter {
 // PlaysOptionSetter is used to handle the func option in plays api
 type PlaysOptionSetter func(*PlaysOption)
 
-// Plays using plays api of the bgg, it get the list of requested items
+// Plays using plays api of the bgg, it get the list of requested items, it returns a single
+// page and ignores the client side filters
 func (bgg *BGG) Plays(ctx context.Context, setter ...PlaysOptionSetter) (*Plays, error) {
 	opt := PlaysOption{}
 	for i := range setter {
@@ -129,6 +221,14 @@ func (bgg *BGG) Plays(ctx context.Context, setter ...PlaysOptionSetter) (*Plays,
 		args["id"] = fmt.Sprint(opt.gameID)
 	}
 
+	if opt.objectType != "" {
+		args["type"] = string(opt.objectType)
+	}
+
+	if opt.subType != "" {
+		args["subtype"] = string(opt.subType)
+	}
+
 	if opt.page > 0 {
 		args["page"] = fmt.Sprint(opt.page)
 	}
@@ -155,7 +255,7 @@ func (bgg *BGG) Plays(ctx context.Context, setter ...PlaysOptionSetter) (*Plays,
 	defer resp.Body.Close()
 
 	var pr playsResponse
-	if err = decode(resp.Body, &pr); err != nil {
+	if err = bgg.decode(EndpointPlays, resp.Body, &pr); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 
@@ -185,17 +285,24 @@ func (bgg *BGG) Plays(ctx context.Context, setter ...PlaysOptionSetter) (*Plays,
 			Players: make([]Player, 0, len(ply.Players.Player)),
 		}
 
+		for _, st := range ply.Item.Subtypes.Subtype {
+			item.Item.Subtypes = append(item.Item.Subtypes, ItemType(st.Value))
+		}
+
 		for _, plr := range ply.Players.Player {
 			item.Players = append(item.Players, Player{
-				UserName:      plr.Username,
-				UserID:        plr.Userid,
-				Name:          plr.Name,
-				StartPosition: plr.StartPosition,
-				Color:         plr.Color,
-				Score:         safeInt(plr.Score),
-				New:           safeInt(plr.New) != 0,
-				Rating:        plr.Rating,
-				Win:           safeInt(plr.Win) != 0,
+				UserName:          plr.Username,
+				UserID:            safeInt(plr.Userid),
+				Name:              plr.Name,
+				StartPosition:     int(safeInt(strings.TrimSpace(plr.StartPosition))),
+				StartPositionText: plr.StartPosition,
+				Color:             plr.Color,
+				Score:             safeFloat64(plr.Score),
+				ScoreText:         plr.Score,
+				NoScore:           strings.TrimSpace(plr.Score) == "",
+				New:               safeInt(plr.New) != 0,
+				Rating:            safeFloat64(plr.Rating),
+				Win:               safeInt(plr.Win) != 0,
 			})
 		}
 
@@ -204,3 +311,35 @@ func (bgg *BGG) Plays(ctx context.Context, setter ...PlaysOptionSetter) (*Plays,
 
 	return &result, nil
 }
+
+// PlaysIter iterates over all the pages of the plays, and applies the client side filters.
+// The iteration stops on the first error
+func (bgg *BGG) PlaysIter(ctx context.Context, setter ...PlaysOptionSetter) iter.Seq2[Play, error] {
+	opt := PlaysOption{}
+	for i := range setter {
+		setter[i](&opt)
+	}
+
+	return func(yield func(Play, error) bool) {
+		for page := max(opt.page, 1); ; page++ {
+			plays, err := bgg.Plays(ctx, slices.Concat(setter, []PlaysOptionSetter{SetPageNumber(page)})...)
+			if err != nil {
+				yield(Play{}, err)
+				return
+			}
+
+			for i := range plays.Items {
+				if !opt.match(&plays.Items[i]) {
+					continue
+				}
+				if !yield(plays.Items[i], nil) {
+					return
+				}
+			}
+
+			if len(plays.Items) == 0 || int64(page*playsPageSize) >= plays.Total {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package gobgg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const playsFixture = `<?xml version="1.0" encoding="utf-8"?>
+<plays username="gobgg" userid="3597059" total="1" page="1" termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<play id="81234" date="2024-01-02" quantity="2" length="45" incomplete="0" nowinstats="1" location="Home">
+		<item name="Ark Nova" objecttype="thing" objectid="342942">
+			<subtypes>
+				<subtype value="boardgame" />
+				<subtype value="boardgameimplementation" />
+			</subtypes>
+		</item>
+		<players>
+			<player username="gobgg" userid="3597059" name="GoBGG" startposition="1" color="Red" score="12.5" new="1" rating="7.5" win="1" />
+			<player username="" userid="0" name="Friend" startposition="dealer" color="" score="DNF" new="0" rating="0" win="0" />
+			<player username="" userid="0" name="Other" startposition="" color="" score="" new="0" rating="" win="0" />
+		</players>
+	</play>
+</plays>`
+
+func TestPlays(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+playsPath,
+		httpmock.NewStringResponder(200, playsFixture))
+
+	bgg := NewBGGClient()
+	plays, err := bgg.Plays(context.Background(), SetUserName("gobgg"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3597059), plays.UserID)
+	require.Len(t, plays.Items, 1)
+
+	play := plays.Items[0]
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), play.Date)
+	assert.Equal(t, 2.0, play.Quantity)
+	assert.Equal(t, []ItemType{BoardGameType, "boardgameimplementation"}, play.Item.Subtypes)
+	require.Len(t, play.Players, 3)
+
+	p := play.Players[0]
+	assert.Equal(t, int64(3597059), p.UserID)
+	assert.Equal(t, 1, p.StartPosition)
+	assert.Equal(t, 12.5, p.Score)
+	assert.Equal(t, "12.5", p.ScoreText)
+	assert.Equal(t, 7.5, p.Rating)
+	assert.True(t, p.Win)
+	assert.True(t, p.New)
+	score, ok := p.NumericScore()
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, score)
+
+	p = play.Players[1]
+	assert.Zero(t, p.StartPosition)
+	assert.Equal(t, "dealer", p.StartPositionString())
+	assert.Zero(t, p.Score)
+	assert.Equal(t, "DNF", p.ScoreString())
+	_, ok = p.NumericScore()
+	assert.False(t, ok)
+
+	p = play.Players[2]
+	assert.Equal(t, "", p.ScoreString())
+	_, ok = p.NumericScore()
+	assert.False(t, ok)
+
+	// A zero score without the text is a real zero
+	zero := Player{Score: 0}
+	assert.Equal(t, "0", zero.ScoreString())
+	_, ok = zero.NumericScore()
+	assert.True(t, ok)
+}
+
+func TestPlaysIter(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	const head = `<?xml version="1.0" encoding="utf-8"?><plays total="150" page="%s">`
+	play := func(id int, location string, incomplete int, player string) string {
+		return fmt.Sprintf(`<play id="%d" date="2024-01-02" quantity="1" length="0" incomplete="%d" nowinstats="1" location="%s">
+		<item name="Game" objecttype="thing" objectid="1" />
+		<players><player username="" userid="0" name="%s" score="" win="0" /></players>
+		</play>`, id, incomplete, location, player)
+	}
+
+	var pages []string
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+playsPath,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			assert.Equal(t, "family", q.Get("type"))
+			assert.Equal(t, "39442", q.Get("id"))
+			assert.Equal(t, "boardgameexpansion", q.Get("subtype"))
+			page := q.Get("page")
+			pages = append(pages, page)
+			body := fmt.Sprintf(head, page)
+			switch page {
+			case "1":
+				body += play(1, "Home", 0, "Alice") + play(2, "Club", 0, "Alice")
+			case "2":
+				body += play(3, " home", 1, "alice") + play(4, "Home", 0, "Bob")
+			}
+			return httpmock.NewStringResponse(200, body+"</plays>"), nil
+		})
+
+	bgg := NewBGGClient()
+	ctx := context.Background()
+	opts := []PlaysOptionSetter{SetFamilyID(39442), SetPlaysSubType(BoardGameExpansionType)}
+
+	var ids []int64
+	for p, err := range bgg.PlaysIter(ctx, opts...) {
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, ids)
+	assert.Equal(t, []string{"1", "2"}, pages)
+
+	ids = nil
+	for p, err := range bgg.PlaysIter(ctx, append(opts, FilterPlayer("ALICE"), FilterLocation("home"))...) {
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int64{1, 3}, ids)
+
+	ids = nil
+	for p, err := range bgg.PlaysIter(ctx, append(opts, FilterComplete(), FilterLocation("home"))...) {
+		require.NoError(t, err)
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []int64{1, 4}, ids)
+
+	_, err := bgg.Plays(ctx, SetPlaysType(PlaysTypeThing))
+	require.Error(t, err)
+}
@@ -51,11 +51,8 @@ type createPlayResponse struct {
 	Error    string `json:"error,omitempty"`
 }
 
-// PostPlay save a play record, you should be logged in, and it returns the number of plays after you save this one
-func (bgg *BGG) PostPlay(ctx context.Context, play *Play) (int, error) {
-	if len(bgg.GetActiveCookies()) == 0 {
-		return 0, fmt.Errorf("call Login first")
-	}
+// PlayPayload returns the JSON payload that PostPlay sends for the play
+func PlayPayload(play *Play) ([]byte, error) {
 	payload := createPlayPayload{
 		Playdate:   play.Date.Format(bggTimeFormat),
 		Comments:   play.Comment,
@@ -77,24 +74,38 @@ func (bgg *BGG) PostPlay(ctx context.Context, play *Play) (int, error) {
 		payload.Players = append(payload.Players, createPlayer{
 			Name:          py.Name,
 			Username:      py.UserName,
-			Userid:        safeInt(py.UserID),
+			Userid:        py.UserID,
 			Avatarfile:    "",
 			Avatar:        false,
 			Selected:      false,
 			Color:         py.Color,
-			Score:         fmt.Sprint(py.Score),
+			Score:         py.ScoreString(),
 			Win:           py.Win,
 			New:           py.New,
 			Disambiguator: 0,
 		})
 	}
 
-	u := bgg.buildURL("geekplay.php", nil)
 	b, err := json.Marshal(payload)
 	if err != nil {
-		return 0, fmt.Errorf("create payload failed: %w", err)
+		return nil, fmt.Errorf("create payload failed: %w", err)
+	}
+
+	return b, nil
+}
+
+// PostPlay save a play record, you should be logged in, and it returns the number of plays after you save this one
+func (bgg *BGG) PostPlay(ctx context.Context, play *Play) (int, error) {
+	if len(bgg.GetActiveCookies()) == 0 {
+		return 0, fmt.Errorf("call Login first")
+	}
+
+	b, err := PlayPayload(play)
+	if err != nil {
+		return 0, err
 	}
 
+	u := bgg.buildURL("geekplay.php", nil)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewBuffer(b))
 	if err != nil {
 		return 0, fmt.Errorf("failed to create the request: %w", err)
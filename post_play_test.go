@@ -41,7 +41,7 @@ func TestPostPlay(t *testing.T) {
 			}, {
 				Name:     "GoBGG",
 				UserName: "gobgg",
-				UserID:   "3597059",
+				UserID:   3597059,
 				Win:      false,
 			},
 		},
@@ -50,3 +50,20 @@ func TestPostPlay(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, num, 0)
 }
+
+func TestPlayPayloadScore(t *testing.T) {
+	payload, err := gobgg.PlayPayload(&gobgg.Play{
+		Date:     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Quantity: 1,
+		Item:     gobgg.Item{ID: 23383, Type: "thing"},
+		Players: []gobgg.Player{
+			{Name: "Forud", Score: 0},
+			{Name: "GoBGG", NoScore: true},
+			{Name: "Friend", ScoreText: "DNF"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(payload), `"name":"Forud","username":"","selected":false,"color":"","score":"0"`)
+	assert.Contains(t, string(payload), `"name":"GoBGG","username":"","selected":false,"color":"","score":""`)
+	assert.Contains(t, string(payload), `"name":"Friend","username":"","selected":false,"color":"","score":"DNF"`)
+}
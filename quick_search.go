@@ -0,0 +1,137 @@
+package gobgg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// quickSearchPath is the site search, it is used for the type-ahead on the site
+const quickSearchPath = "search/%s"
+
+type quickSearchStruct struct {
+	Items []struct {
+		Objecttype    string `json:"objecttype"`
+		Subtype       string `json:"subtype"`
+		Objectid      string `json:"objectid"`
+		Name          string `json:"name"`
+		Href          string `json:"href"`
+		Yearpublished any    `json:"yearpublished"`
+		Imageurl      string `json:"imageurl"`
+		Images        struct {
+			Thumb string `json:"thumb"`
+			Micro string `json:"micro"`
+		} `json:"images"`
+	} `json:"items"`
+}
+
+// QuickSearchResult is a single result of the quick search
+type QuickSearchResult struct {
+	ID            int64    `json:"id"`
+	Name          string   `json:"name"`
+	Type          ItemType `json:"type"`
+	YearPublished int      `json:"year_published,omitempty"`
+	Thumbnail     string   `json:"thumbnail,omitempty"`
+	Href          string   `json:"href"`
+}
+
+// quickSearchCount is the number of results for each type
+const quickSearchCount = 20
+
+func (bgg *BGG) quickSearch(ctx context.Context, query string, typ ItemType) ([]QuickSearchResult, error) {
+	u := bgg.buildURL(fmt.Sprintf(quickSearchPath, typ), map[string]string{
+		"q":         query,
+		"nosession": "1",
+		"showcount": fmt.Sprint(quickSearchCount),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := bgg.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %q", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read failed: %w", err)
+	}
+	result := quickSearchStruct{}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("JSON parsing failed: %w", err)
+	}
+
+	final := make([]QuickSearchResult, len(result.Items))
+	for i := range result.Items {
+		item := &result.Items[i]
+		thumb := item.Images.Thumb
+		if thumb == "" {
+			thumb = item.Images.Micro
+		}
+		if thumb == "" {
+			thumb = item.Imageurl
+		}
+		sub := ItemType(item.Subtype)
+		if sub == "" {
+			sub = typ
+		}
+		final[i] = QuickSearchResult{
+			ID:            safeInt(item.Objectid),
+			Name:          item.Name,
+			Type:          sub,
+			YearPublished: int(safeIntInterface(item.Yearpublished)),
+			Thumbnail:     thumb,
+			Href:          bgg.buildURL(item.Href, nil),
+		}
+	}
+
+	return final, nil
+}
+
+// QuickSearch uses the site search (the same one that is used in the site type-ahead), it is faster
+// than the Search and the results are sorted by popularity. Default type is board game, for
+// multiple types the results are interleaved, so the most popular items of each type come first
+func (bgg *BGG) QuickSearch(ctx context.Context, query string, types ...ItemType) ([]QuickSearchResult, error) {
+	if len(types) == 0 {
+		types = []ItemType{BoardGameType}
+	}
+
+	all := make([][]QuickSearchResult, len(types))
+	longest := 0
+	for i := range types {
+		res, err := bgg.quickSearch(ctx, query, types[i])
+		if err != nil {
+			return nil, err
+		}
+		all[i] = res
+		longest = max(longest, len(res))
+	}
+
+	var (
+		final []QuickSearchResult
+		seen  = make(map[int64]bool)
+	)
+	for idx := 0; idx < longest; idx++ {
+		for i := range all {
+			if idx >= len(all[i]) || seen[all[i][idx].ID] {
+				continue
+			}
+			seen[all[i][idx].ID] = true
+			final = append(final, all[i][idx])
+		}
+	}
+
+	return final, nil
+}
@@ -0,0 +1,55 @@
+package gobgg
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickSearch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/search/boardgame",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "catan", req.URL.Query().Get("q"))
+			assert.Equal(t, "application/json", req.Header.Get("Accept"))
+			return httpmock.NewStringResponse(200, `{"items":[
+				{"objecttype":"thing","subtype":"boardgame","objectid":"13","name":"CATAN","href":"/boardgame/13/catan","yearpublished":1995,"images":{"thumb":"https://cf.geekdo-images.com/thumb.jpg"}},
+				{"objecttype":"thing","subtype":"boardgame","objectid":"926","name":"Catan: Cities & Knights","href":"/boardgameexpansion/926/catan-cities-knights","yearpublished":"1998","imageurl":"https://cf.geekdo-images.com/926.jpg"}
+			]}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/search/rpgitem",
+		httpmock.NewStringResponder(200, `{"items":[
+			{"objecttype":"thing","objectid":"5000","name":"Catan RPG","href":"/rpgitem/5000/catan-rpg"},
+			{"objecttype":"thing","subtype":"boardgame","objectid":"13","name":"CATAN","href":"/boardgame/13/catan"}
+		]}`))
+
+	bgg := NewBGGClient()
+	items, err := bgg.QuickSearch(context.Background(), "catan")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, QuickSearchResult{
+		ID:            13,
+		Name:          "CATAN",
+		Type:          BoardGameType,
+		YearPublished: 1995,
+		Thumbnail:     "https://cf.geekdo-images.com/thumb.jpg",
+		Href:          "https://boardgamegeek.com/boardgame/13/catan",
+	}, items[0])
+	assert.Equal(t, 1998, items[1].YearPublished)
+	assert.Equal(t, "https://cf.geekdo-images.com/926.jpg", items[1].Thumbnail)
+
+	items, err = bgg.QuickSearch(context.Background(), "catan", BoardGameType, RPGItemType)
+	require.NoError(t, err)
+	ids := make([]int64, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+	assert.Equal(t, []int64{13, 5000, 926}, ids)
+	assert.Equal(t, RPGItemType, items[1].Type)
+}
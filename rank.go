@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 )
 
@@ -70,35 +71,68 @@ func (bgg *BGG) myCollections(ctx context.Context, objectID int64) (*rankRespons
 	return &items, nil
 }
 
-// SetRank tries to add rank for an item (experimental)
-func (bgg *BGG) SetRank(ctx context.Context, objectID int64, rate float64) error {
+// RatePayload returns the JSON payload that SetRank sends, the item is the collection item from
+// the site API and only its rating is changed
+func RatePayload(item map[string]any, rate float64) ([]byte, error) {
+	update := rankRequest{
+		Item: maps.Clone(item),
+	}
+	if update.Item == nil {
+		update.Item = make(map[string]any)
+	}
+	update.Item["rating"] = rate
+
+	b, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling json failed: %w", err)
+	}
+
+	return b, nil
+}
+
+// rankPayload reads the collection item of the game, and returns its collid and the payload to
+// set the rate
+func (bgg *BGG) rankPayload(ctx context.Context, objectID int64, rate float64) (string, []byte, error) {
 	if rate <= 0 || rate > 10 {
-		return fmt.Errorf("invalid rate range: %f", rate)
+		return "", nil, fmt.Errorf("invalid rate range: %f", rate)
 	}
 
 	items, err := bgg.myCollections(ctx, objectID)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
 	if len(items.Items) == 0 {
-		return fmt.Errorf("no item found")
+		return "", nil, fmt.Errorf("no item found")
 	}
 
 	item := items.Items[0]
 	collid, ok := item["collid"].(string)
 	if !ok {
-		return fmt.Errorf("the response has no collid")
+		return "", nil, fmt.Errorf("the response has no collid")
 	}
 
-	item["rating"] = rate
-
-	update := rankRequest{
-		Item: item,
+	b, err := RatePayload(item, rate)
+	if err != nil {
+		return "", nil, err
 	}
-	b, err := json.Marshal(update)
+
+	return collid, b, nil
+}
+
+// RankPayload returns the payload that SetRank sends for the item, it only reads the collection
+// item (so it needs the login too) and does not change anything
+func (bgg *BGG) RankPayload(ctx context.Context, objectID int64, rate float64) ([]byte, error) {
+	_, b, err := bgg.rankPayload(ctx, objectID, rate)
+
+	return b, err
+}
+
+// SetRank tries to add rank for an item (experimental)
+func (bgg *BGG) SetRank(ctx context.Context, objectID int64, rate float64) error {
+	collid, b, err := bgg.rankPayload(ctx, objectID, rate)
 	if err != nil {
-		return fmt.Errorf("marshaling json failed: %w", err)
+		return err
 	}
 
 	url := bgg.buildURL(fmt.Sprintf(apiCollectionItemUrl, collid), nil)
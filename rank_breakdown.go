@@ -0,0 +1,170 @@
+package gobgg
+
+import (
+	"errors"
+	"math"
+)
+
+// bggPriorRating is the rating that BGG uses for the dummy votes in the geek rating
+const bggPriorRating = 5.5
+
+// maxStdDev is the maximum standard deviation for the ratings between 1 and 10 (half the votes
+// are 1 and the other half are 10)
+const maxStdDev = 4.5
+
+// StdDev is the standard deviation of the ratings
+func (rb RankBreakDown) StdDev() float64 {
+	total := rb.Total()
+	if total == 0 {
+		return 0
+	}
+
+	avg := rb.Average()
+	var sum float64
+	for i := range rb {
+		d := float64(i+1) - avg
+		sum += d * d * float64(rb[i])
+	}
+
+	return math.Sqrt(sum / float64(total))
+}
+
+// Percentile returns the rating that p percent (0-100) of the votes are equal or lower than it,
+// it returns zero if there is no vote
+func (rb RankBreakDown) Percentile(p float64) int {
+	total := rb.Total()
+	if total == 0 {
+		return 0
+	}
+
+	need := math.Max(1, math.Ceil(float64(total)*p/100))
+	var cum int64
+	for i := range rb {
+		cum += rb[i]
+		if float64(cum) >= need {
+			return i + 1
+		}
+	}
+
+	return len(rb)
+}
+
+// Median is the 50th percentile
+func (rb RankBreakDown) Median() int {
+	return rb.Percentile(50)
+}
+
+// Mode is the rating with the most votes, the lower rating wins on a tie
+func (rb RankBreakDown) Mode() int {
+	mode := 0
+	for i := range rb {
+		if rb[i] > 0 && (mode == 0 || rb[i] > rb[mode-1]) {
+			mode = i + 1
+		}
+	}
+
+	return mode
+}
+
+// Entropy is the normalized Shannon entropy of the ratings, zero means all the votes are the
+// same and one means the votes are spread evenly
+func (rb RankBreakDown) Entropy() float64 {
+	total := rb.Total()
+	if total == 0 {
+		return 0
+	}
+
+	var h float64
+	for i := range rb {
+		if rb[i] == 0 {
+			continue
+		}
+		p := float64(rb[i]) / float64(total)
+		h -= p * math.Log(p)
+	}
+
+	return h / math.Log(float64(len(rb)))
+}
+
+// Polarization is the standard deviation relative to the maximum possible one, it is between
+// zero (everybody agrees) and one (half of the votes are 1 and the other half are 10)
+func (rb RankBreakDown) Polarization() float64 {
+	return rb.StdDev() / maxStdDev
+}
+
+// VotesToReach returns the number of votes with the given rating needed to reach the target
+// bayesian average (geek rating) with the dummy votes. It returns zero if the target is
+// already reached and -1 if it is not reachable with that rating
+func (rb RankBreakDown) VotesToReach(target float64, rating int, dummy int64) int64 {
+	if rb.BayesianAverage(dummy) >= target {
+		return 0
+	}
+
+	r := float64(rating)
+	if r <= target {
+		return -1
+	}
+
+	var sum float64
+	for i := range rb {
+		sum += float64(i+1) * float64(rb[i])
+	}
+	sum += float64(dummy) * bggPriorRating
+	n := float64(rb.Total() + dummy)
+
+	return int64(math.Ceil((target*n - sum) / (r - target)))
+}
+
+// BreakDownComparison is the result of comparing two rank break downs
+type BreakDownComparison struct {
+	// Diff is the number of votes of each rating in the other break down minus this one
+	Diff        RankBreakDown
+	AverageDiff float64
+	BayesDiff   float64
+	StdDevDiff  float64
+	// TensToReach is the number of 10 votes to reach the other geek rating, -1 if it is not
+	// reachable
+	TensToReach int64
+}
+
+// Compare compares this break down with the other one, for example the game at the target rank,
+// the dummy is the number of dummy votes used for the geek rating (see EstimateDummyVotes)
+func (rb RankBreakDown) Compare(other RankBreakDown, dummy int64) BreakDownComparison {
+	cmp := BreakDownComparison{
+		AverageDiff: other.Average() - rb.Average(),
+		BayesDiff:   other.BayesianAverage(dummy) - rb.BayesianAverage(dummy),
+		StdDevDiff:  other.StdDev() - rb.StdDev(),
+		TensToReach: rb.VotesToReach(other.BayesianAverage(dummy), 10, dummy),
+	}
+	for i := range rb {
+		cmp.Diff[i] = other[i] - rb[i]
+	}
+
+	return cmp
+}
+
+// EstimateDummyVotes estimates the number of dummy votes (with the 5.5 rating) that BGG adds
+// to calculate the geek rating, based on the average, the geek rating and the number of votes.
+// The result is rounded to be used with BayesianAverage and Compare, and it is only meaningful
+// for items with enough votes
+func EstimateDummyVotes(average, bayesAverage float64, usersRated int) (int64, error) {
+	if usersRated <= 0 || bayesAverage == 0 {
+		return 0, errors.New("the item is not rated")
+	}
+
+	if math.Abs(bayesAverage-bggPriorRating) < 1e-9 {
+		return 0, errors.New("the geek rating is equal to the prior rating")
+	}
+
+	dummy := float64(usersRated) * (average - bayesAverage) / (bayesAverage - bggPriorRating)
+	if dummy < 0 {
+		return 0, errors.New("the geek rating is not between the prior and the average")
+	}
+
+	return int64(math.Round(dummy)), nil
+}
+
+// DummyVotes estimates the number of dummy votes using the thing statistics
+func (tr *ThingResult) DummyVotes() (int64, error) {
+	return EstimateDummyVotes(tr.AverageRate, tr.BayesAverage, tr.UsersRated)
+}
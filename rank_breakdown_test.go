@@ -0,0 +1,90 @@
+package gobgg
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBreakDownStats(t *testing.T) {
+	polar := RankBreakDown{1, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	assert.Equal(t, 5.5, polar.Average())
+	assert.InDelta(t, 4.5, polar.StdDev(), 1e-9)
+	assert.InDelta(t, 1, polar.Polarization(), 1e-9)
+	assert.InDelta(t, math.Log(2)/math.Log(10), polar.Entropy(), 1e-9)
+	assert.Equal(t, 1, polar.Median())
+	assert.Equal(t, 1, polar.Mode())
+
+	rb := RankBreakDown{0, 0, 0, 0, 2, 3, 5, 0, 0, 0}
+	assert.InDelta(t, 6.3, rb.Average(), 1e-9)
+	assert.Equal(t, 6, rb.Median())
+	assert.Equal(t, 7, rb.Mode())
+	assert.Equal(t, 5, rb.Percentile(0))
+	assert.Equal(t, 7, rb.Percentile(90))
+	assert.Equal(t, 7, rb.Percentile(100))
+
+	var empty RankBreakDown
+	assert.Zero(t, empty.StdDev())
+	assert.Zero(t, empty.Median())
+	assert.Zero(t, empty.Mode())
+	assert.Zero(t, empty.Entropy())
+
+	// (63 + 10*5.5) / 20 = 5.9
+	assert.InDelta(t, 5.9, rb.BayesianAverage(10), 1e-9)
+	assert.Equal(t, int64(1), rb.VotesToReach(6, 10, 10))
+	assert.Equal(t, int64(0), rb.VotesToReach(5, 10, 10))
+	assert.Equal(t, int64(-1), rb.VotesToReach(6, 6, 10))
+
+	other := rb
+	other[9] = 2
+	cmp := rb.Compare(other, 10)
+	assert.Equal(t, RankBreakDown{0, 0, 0, 0, 0, 0, 0, 0, 0, 2}, cmp.Diff)
+	assert.Equal(t, int64(2), cmp.TensToReach)
+	assert.Greater(t, cmp.BayesDiff, 0.0)
+}
+
+func TestEstimateDummyVotes(t *testing.T) {
+	// 1000 votes with the average of 8 and 500 dummy votes
+	bayes := (8000 + 500*5.5) / 1500
+	thing := ThingResult{AverageRate: 8, BayesAverage: bayes, UsersRated: 1000}
+	dummy, err := thing.DummyVotes()
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), dummy)
+
+	_, err = EstimateDummyVotes(8, 0, 0)
+	require.Error(t, err)
+	_, err = EstimateDummyVotes(8, 5.5, 10)
+	require.Error(t, err)
+}
+
+func TestGetRankBreakDown(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+rankPath,
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			assert.Equal(t, "12", q.Get("objectid"))
+			assert.Equal(t, "family", q.Get("objecttype"))
+			assert.Equal(t, "weight", q.Get("ratingtype"))
+			return httpmock.NewStringResponse(200, `{"type":"BarChart","data":{"cols":[],"rows":[
+				{"c":[{"v":"1"},{"v":3}]},{"c":[{"v":"10"},{"v":"7"}]},{"c":[{"v":"11"},{"v":1}]}]}}`), nil
+		})
+
+	bgg := NewBGGClient()
+	wb, err := bgg.GetWeightBreakDown(context.Background(), 12, RankBreakDownType(RankBreakDownFamily))
+	require.NoError(t, err)
+	assert.Equal(t, WeightBreakDown{3, 0, 0, 0, 0}, wb)
+	assert.Equal(t, 1.0, wb.Average())
+
+	// The weight is not on the rating scale
+	_, err = bgg.GetRankBreakDown(context.Background(), 12,
+		RankBreakDownType(RankBreakDownFamily), RankBreakDownRatings(RankBreakDownWeight))
+	require.Error(t, err)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
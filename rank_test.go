@@ -0,0 +1,68 @@
+package gobgg
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRank(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/user",
+		httpmock.NewStringResponder(200, `<user id="1000001" name="gobgg"><yearregistered value="2015"/></user>`))
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/api/collections",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "1000001", req.URL.Query().Get("userid"))
+			assert.Equal(t, "thing", req.URL.Query().Get("objecttype"))
+			if req.URL.Query().Get("objectid") != "224517" {
+				return httpmock.NewStringResponse(200, `{"items":[]}`), nil
+			}
+			return httpmock.NewStringResponse(200, `{"items":[{"collid":"42","objectid":"224517","rating":null,"textfield":{"comment":"nice"}}]}`), nil
+		})
+
+	var sent map[string]map[string]any
+	httpmock.RegisterResponder("PUT", "https://boardgamegeek.com/api/collectionitems/42",
+		func(req *http.Request) (*http.Response, error) {
+			cookie, err := req.Cookie("SessionID")
+			require.NoError(t, err)
+			assert.Equal(t, "abcd", cookie.Value)
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&sent))
+			return httpmock.NewStringResponse(200, `{}`), nil
+		})
+
+	bgg := NewBGGClient()
+	require.Error(t, bgg.SetRank(context.Background(), 224517, 7.5))
+
+	bgg = NewBGGClient(SetCookies("gobgg", []*http.Cookie{{Name: "SessionID", Value: "abcd"}}))
+	require.NoError(t, bgg.SetRank(context.Background(), 224517, 7.5))
+	assert.Equal(t, 7.5, sent["item"]["rating"])
+	assert.Equal(t, "42", sent["item"]["collid"])
+	// The other fields of the item are sent back unchanged
+	assert.Equal(t, map[string]any{"comment": "nice"}, sent["item"]["textfield"])
+
+	// The payload is the same as the one that is sent, and nothing is sent for it
+	b, err := bgg.RankPayload(context.Background(), 224517, 6)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"item":{"collid":"42","objectid":"224517","rating":6,"textfield":{"comment":"nice"}}}`, string(b))
+	assert.Equal(t, 1, httpmock.GetCallCountInfo()["PUT https://boardgamegeek.com/api/collectionitems/42"])
+
+	require.Error(t, bgg.SetRank(context.Background(), 224517, 11))
+	require.Error(t, bgg.SetRank(context.Background(), 13, 8))
+
+	b, err = RatePayload(nil, 8)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"item":{"rating":8}}`, string(b))
+
+	item := map[string]any{"collid": "42", "rating": nil}
+	b, err = RatePayload(item, 9)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"item":{"collid":"42","rating":9}}`, string(b))
+	assert.Nil(t, item["rating"], "the item is not changed")
+}
@@ -0,0 +1,291 @@
+package gobgg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// RankingsSort is the sort order of the rankings
+type RankingsSort string
+
+const (
+	// RankingsSortRank sorts by the rank (geek rating), it is the default
+	RankingsSortRank RankingsSort = "rank"
+	// RankingsSortAverage sorts by the average rating
+	RankingsSortAverage RankingsSort = "avgrating"
+	// RankingsSortNumVoters sorts by the number of voters
+	RankingsSortNumVoters RankingsSort = "numvoters"
+)
+
+// Subdomain is the board game subdomain, the value is the same as the rank name in the thing
+// statistics
+type Subdomain string
+
+const (
+	SubdomainAbstract     Subdomain = "abstracts"
+	SubdomainChildren     Subdomain = "childrensgames"
+	SubdomainCustomizable Subdomain = "cgs"
+	SubdomainFamily       Subdomain = "familygames"
+	SubdomainParty        Subdomain = "partygames"
+	SubdomainStrategy     Subdomain = "strategygames"
+	SubdomainThematic     Subdomain = "thematic"
+	SubdomainWargames     Subdomain = "wargames"
+)
+
+// subdomainIDs are the ids of the subdomains, the browse page uses them as the subdomain rank object
+var subdomainIDs = map[Subdomain]int64{
+	SubdomainAbstract:     4666,
+	SubdomainChildren:     4665,
+	SubdomainCustomizable: 4667,
+	SubdomainFamily:       5499,
+	SubdomainParty:        5498,
+	SubdomainStrategy:     5497,
+	SubdomainThematic:     5496,
+	SubdomainWargames:     4664,
+}
+
+// RankingItem is a single row in the rankings
+type RankingItem struct {
+	Rank          int     `json:"rank"` // Zero means not ranked (or unknown)
+	ID            int64   `json:"id"`
+	Name          string  `json:"name"`
+	YearPublished int     `json:"year_published,omitempty"`
+	GeekRating    float64 `json:"geek_rating"`
+	AverageRating float64 `json:"average_rating"`
+	NumVoters     int     `json:"num_voters"`
+	Thumbnail     string  `json:"thumbnail,omitempty"`
+}
+
+// RankingsOption is the option for the rankings
+type RankingsOption struct {
+	page      int
+	sort      RankingsSort
+	subdomain Subdomain
+}
+
+// RankingsOptionSetter is the setter for the rankings option
+type RankingsOptionSetter func(*RankingsOption)
+
+// RankingsPage sets the page number, each page has 100 items, default is the first page
+func RankingsPage(page int) RankingsOptionSetter {
+	return func(opt *RankingsOption) {
+		opt.page = page
+	}
+}
+
+// RankingsSortBy sets the sort order
+func RankingsSortBy(sort RankingsSort) RankingsOptionSetter {
+	return func(opt *RankingsOption) {
+		opt.sort = sort
+	}
+}
+
+// RankingsSubdomain limits the rankings to a subdomain, the rank is the rank in the subdomain
+func RankingsSubdomain(subdomain Subdomain) RankingsOptionSetter {
+	return func(opt *RankingsOption) {
+		opt.subdomain = subdomain
+	}
+}
+
+func hasClass(node *html.Node, class string) bool {
+	for _, c := range strings.Fields(getAttr(node.Attr, "class")) {
+		if c == class {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nodeText(node *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func findNode(node *html.Node, match func(*html.Node) bool) *html.Node {
+	if node.Type == html.ElementNode && match(node) {
+		return node
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findNode(child, match); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+func parseRankingRow(row *html.Node) RankingItem {
+	var (
+		item    RankingItem
+		ratings []string
+	)
+	for td := row.FirstChild; td != nil; td = td.NextSibling {
+		if td.Type != html.ElementNode || td.Data != "td" {
+			continue
+		}
+		switch {
+		case hasClass(td, "collection_rank"):
+			item.Rank = int(safeInt(nodeText(td)))
+		case hasClass(td, "collection_thumbnail"):
+			if img := findNode(td, func(n *html.Node) bool { return n.Data == "img" }); img != nil {
+				item.Thumbnail = getAttr(img.Attr, "src")
+			}
+		case hasClass(td, "collection_objectname"):
+			if a := findNode(td, func(n *html.Node) bool { return n.Data == "a" && hasClass(n, "primary") }); a != nil {
+				item.ID = getID(getAttr(a.Attr, "href"), "boardgame")
+				item.Name = nodeText(a)
+			}
+			if span := findNode(td, func(n *html.Node) bool { return n.Data == "span" && hasClass(n, "dull") }); span != nil {
+				item.YearPublished = int(safeInt(strings.Trim(nodeText(span), "()")))
+			}
+		case hasClass(td, "collection_bggrating"):
+			ratings = append(ratings, nodeText(td))
+		}
+	}
+
+	// The rating columns are geek rating, average rating and the number of voters
+	if len(ratings) > 0 {
+		item.GeekRating = safeFloat64(ratings[0])
+	}
+	if len(ratings) > 1 {
+		item.AverageRating = safeFloat64(ratings[1])
+	}
+	if len(ratings) > 2 {
+		item.NumVoters = int(safeInt(ratings[2]))
+	}
+
+	return item
+}
+
+// linkRankingItem returns the item for the first primary link in the node, it only has the name
+// and id
+func linkRankingItem(node *html.Node) RankingItem {
+	var item RankingItem
+	findNode(node, func(n *html.Node) bool {
+		if n.Data != "a" || !hasClass(n, "primary") {
+			return false
+		}
+		if id := getID(getAttr(n.Attr, "href"), "boardgame"); id > 0 {
+			item = RankingItem{ID: id, Name: nodeText(n)}
+			return true
+		}
+		return false
+	})
+
+	return item
+}
+
+// parseRankings reads the rows of the browse table, if a row is not recognised (the markup is
+// changed) or there is no table, it falls back to the primary links, which only have the name and
+// id, the rank of them is their position when the page is sorted by rank
+func parseRankings(doc *html.Node, firstRank int) []RankingItem {
+	var result []RankingItem
+	add := func(item RankingItem, fallback bool) {
+		if item.ID <= 0 {
+			return
+		}
+		if fallback && firstRank > 0 {
+			item.Rank = firstRank + len(result)
+		}
+		result = append(result, item)
+	}
+
+	var crawler func(*html.Node)
+	crawler = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			switch {
+			case node.Data == "tr" && strings.HasPrefix(getAttr(node.Attr, "id"), "row_"):
+				if item := parseRankingRow(node); item.ID > 0 {
+					add(item, false)
+				} else {
+					add(linkRankingItem(node), true)
+				}
+				return
+			case node.Data == "a" && hasClass(node, "primary"):
+				add(linkRankingItem(node), true)
+				return
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			crawler(child)
+		}
+	}
+	crawler(doc)
+
+	return result
+}
+
+// Rankings returns the board game rankings from the browse pages, 100 items per page
+func (bgg *BGG) Rankings(ctx context.Context, setters ...RankingsOptionSetter) ([]RankingItem, error) {
+	opt := RankingsOption{
+		page: 1,
+		sort: RankingsSortRank,
+	}
+	for i := range setters {
+		setters[i](&opt)
+	}
+
+	if opt.page < 1 {
+		return nil, fmt.Errorf("invalid page: %d", opt.page)
+	}
+
+	args := map[string]string{
+		"sort":    string(opt.sort),
+		"sortdir": "desc",
+	}
+	if opt.sort == RankingsSortRank {
+		args["sortdir"] = "asc"
+	}
+	if opt.subdomain != "" {
+		id, ok := subdomainIDs[opt.subdomain]
+		if !ok {
+			return nil, fmt.Errorf("invalid subdomain: %q", opt.subdomain)
+		}
+		args["rankobjecttype"] = "subdomain"
+		args["rankobjectid"] = fmt.Sprint(id)
+	}
+
+	u := bgg.buildURL(fmt.Sprintf(topPages, opt.page), args)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	resp, err := bgg.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %q", resp.Status)
+	}
+
+	doc, err := html.Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse html failed: %w", err)
+	}
+
+	firstRank := 0
+	if opt.sort == RankingsSortRank {
+		firstRank = (opt.page-1)*100 + 1
+	}
+	return parseRankings(doc, firstRank), nil
+}
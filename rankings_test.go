@@ -0,0 +1,103 @@
+package gobgg
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankings(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/browse.html")
+	require.NoError(t, err)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/browse/boardgame/page/1",
+		func(req *http.Request) (*http.Response, error) {
+			q := req.URL.Query()
+			assert.Equal(t, "numvoters", q.Get("sort"))
+			assert.Equal(t, "desc", q.Get("sortdir"))
+			assert.Equal(t, "subdomain", q.Get("rankobjecttype"))
+			assert.Equal(t, "5497", q.Get("rankobjectid"))
+			return httpmock.NewBytesResponse(200, fixture), nil
+		})
+
+	bgg := NewBGGClient()
+	items, err := bgg.Rankings(context.Background(), RankingsSortBy(RankingsSortNumVoters), RankingsSubdomain(SubdomainStrategy))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, RankingItem{
+		Rank:          1,
+		ID:            224517,
+		Name:          "Brass: Birmingham",
+		YearPublished: 2018,
+		GeekRating:    8.408,
+		AverageRating: 8.59,
+		NumVoters:     47331,
+		Thumbnail:     "https://cf.geekdo-images.com/brass__micro/img/brass.jpg",
+	}, items[0])
+	assert.Equal(t, 2, items[1].Rank)
+	assert.Equal(t, int64(161936), items[1].ID)
+	assert.Equal(t, RankingItem{ID: 400000, Name: "Unranked Game", AverageRating: 7.1, NumVoters: 12}, items[2])
+
+	_, err = bgg.Rankings(context.Background(), RankingsSubdomain("unknown"))
+	require.Error(t, err)
+}
+
+func TestRankingsFallback(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/browse/boardgame/page/2",
+		httpmock.NewStringResponder(200, `<html><body><ul>
+			<li><a class="primary" href="/boardgame/13/catan">CATAN</a></li>
+			<li><a class="primary other" href="/boardgame/822/carcassonne">Carcassonne</a></li>
+			<li><a class="primary" href="/boardgamefamily/5497/strategy">Strategy</a></li>
+		</ul></body></html>`))
+
+	bgg := NewBGGClient()
+	items, err := bgg.Rankings(context.Background(), RankingsPage(2))
+	require.NoError(t, err)
+	assert.Equal(t, []RankingItem{
+		{Rank: 101, ID: 13, Name: "CATAN"},
+		{Rank: 102, ID: 822, Name: "Carcassonne"},
+	}, items)
+
+	ids, err := bgg.TopPages(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{13, 822}, ids)
+}
+
+func TestRankingsMixedRows(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The second row has an unknown markup, only its link is used
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/browse/boardgame/page/1",
+		httpmock.NewStringResponder(200, `<html><body><table id="collectionitems">
+			<tr id="row_"><td class="collection_rank">1</td><td class="collection_objectname">
+				<a class="primary" href="/boardgame/224517/brass-birmingham">Brass: Birmingham</a>
+				<span class="smallerfont dull">(2018)</span></td>
+				<td class="collection_bggrating">8.408</td></tr>
+			<tr id="row_"><td class="game_rank">2</td><td class="game_name">
+				<div><a class="primary" href="/boardgame/161936/pandemic-legacy-season-1">Pandemic Legacy: Season 1</a></div></td></tr>
+			<tr id="row_"><td class="collection_rank">3</td><td class="collection_objectname">
+				<a class="primary" href="/boardgame/342942/ark-nova">Ark Nova</a></td></tr>
+			<tr id="row_"><td>Advertisement</td></tr>
+		</table></body></html>`))
+
+	bgg := NewBGGClient()
+	items, err := bgg.Rankings(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []RankingItem{
+		{Rank: 1, ID: 224517, Name: "Brass: Birmingham", YearPublished: 2018, GeekRating: 8.408},
+		{Rank: 2, ID: 161936, Name: "Pandemic Legacy: Season 1"},
+		{Rank: 3, ID: 342942, Name: "Ark Nova"},
+	}, items)
+}
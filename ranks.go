@@ -0,0 +1,107 @@
+package gobgg
+
+import "strings"
+
+// RankKind is the kind of the rank, the subtype rank is the overall rank of the item in its
+// subtype (board game, RPG item, video game...) and family ranks are the subdomain ranks
+type RankKind string
+
+const (
+	// RankKindSubtype is the overall rank in the subtype
+	RankKindSubtype RankKind = "subtype"
+	// RankKindFamily is the rank in a family (subdomain), like strategygames
+	RankKindFamily RankKind = "family"
+)
+
+type rankStruct struct {
+	Text         string `xml:",chardata"`
+	Type         string `xml:"type,attr"`
+	ID           string `xml:"id,attr"`
+	Name         string `xml:"name,attr"`
+	Friendlyname string `xml:"friendlyname,attr"`
+	Value        string `xml:"value,attr"`
+	Bayesaverage string `xml:"bayesaverage,attr"`
+}
+
+// Rank is a single rank entry of an item
+type Rank struct {
+	Kind         RankKind `json:"kind"`
+	ID           int64    `json:"id,omitempty"`
+	Name         string   `json:"name"`
+	FriendlyName string   `json:"friendly_name,omitempty"`
+	// Ranked is false when BGG reports the item as "Not Ranked" in this list, the Value is
+	// zero in that case
+	Ranked       bool    `json:"ranked"`
+	Value        int     `json:"value,omitempty"`
+	BayesAverage float64 `json:"bayes_average,omitempty"`
+}
+
+// Ranks is all the rank entries of an item, in the same order as BGG returns them
+type Ranks []Rank
+
+// RankIn returns the rank by its name (like "boardgame" or "strategygames"), the second return
+// value is false if the item is not in that list at all
+func (r Ranks) RankIn(name string) (Rank, bool) {
+	for i := range r {
+		if strings.EqualFold(r[i].Name, name) {
+			return r[i], true
+		}
+	}
+
+	return Rank{}, false
+}
+
+// Subtype returns the overall rank of the item
+func (r Ranks) Subtype() (Rank, bool) {
+	for i := range r {
+		if r[i].Kind == RankKindSubtype {
+			return r[i], true
+		}
+	}
+
+	return Rank{}, false
+}
+
+// Families returns the family (subdomain) ranks
+func (r Ranks) Families() Ranks {
+	var result Ranks
+	for i := range r {
+		if r[i].Kind == RankKindFamily {
+			result = append(result, r[i])
+		}
+	}
+
+	return result
+}
+
+func parseRanks(in []rankStruct) Ranks {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make(Ranks, 0, len(in))
+	for i := range in {
+		value := safeInt(in[i].Value)
+		result = append(result, Rank{
+			Kind:         RankKind(in[i].Type),
+			ID:           safeInt(in[i].ID),
+			Name:         in[i].Name,
+			FriendlyName: in[i].Friendlyname,
+			Ranked:       value > 0,
+			Value:        int(value),
+			BayesAverage: safeFloat64(in[i].Bayesaverage),
+		})
+	}
+
+	return result
+}
+
+// RankIn returns the rank by its name, like "boardgame" or "strategygames"
+func (tr *ThingResult) RankIn(name string) (Rank, bool) {
+	return tr.Ranks.RankIn(name)
+}
+
+// RankIn returns the rank by its name, the ranks are only available when the stats are requested
+func (ci *CollectionItem) RankIn(name string) (Rank, bool) {
+	return ci.Ranks.RankIn(name)
+}
@@ -0,0 +1,119 @@
+package gobgg
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy is the retry policy of the client for the transient errors, the 429 and 5xx status
+// codes and the BGG rate limit error (that comes with the 200 status code). Only the GET and HEAD
+// requests are retried, the writes are not safe to repeat. Each attempt goes through the limiter
+type RetryPolicy struct {
+	// MaxRetries is the number of the retries, zero disables the retry
+	MaxRetries int
+	// Delay is the delay before the first retry, it is doubled for each retry, the Retry-After
+	// header overrides it
+	Delay time.Duration
+	// MaxDelay is the maximum delay, zero means no limit
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is a sensible policy for the BGG rate limit, the retry is disabled by default
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	Delay:      2 * time.Second,
+	MaxDelay:   30 * time.Second,
+}
+
+// SetRetryPolicy sets the retry policy, it is shared between all the callers of the client
+func SetRetryPolicy(policy RetryPolicy) OptionSetter {
+	return func(bgg *BGG) {
+		bgg.retry = policy
+	}
+}
+
+// backoff returns the delay before the retry, the attempt starts from zero
+func (p *RetryPolicy) backoff(attempt int, header http.Header) time.Duration {
+	if d, ok := retryAfter(header.Get("Retry-After")); ok {
+		return d
+	}
+
+	delay := p.Delay
+	for i := 0; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// retryAfter parses the Retry-After header, it is the number of seconds or a HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if sec, err := strconv.Atoi(value); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+// shouldRetry checks if the response is a transient error, the body of the XML responses is read
+// to find the rate limit error, and it is replaced with a reader for the same content
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, attempt int) bool {
+	if attempt >= p.MaxRetries || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= http.StatusInternalServerError:
+		return true
+	case resp.StatusCode == http.StatusOK && strings.Contains(resp.Header.Get("Content-Type"), "xml"):
+		return isRateLimited(resp)
+	}
+
+	return false
+}
+
+func isRateLimited(resp *http.Response) bool {
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+
+	var envelope bggError
+	if xml.Unmarshal(b, &envelope) != nil {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(envelope.Message), "rate limit")
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,105 @@
+package gobgg
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countLimiter int
+
+func (c *countLimiter) Take() time.Time {
+	*c++
+	return time.Now()
+}
+
+func TestRetryPolicy(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	xmlResponse := func(status int, body string) *http.Response {
+		resp := httpmock.NewStringResponse(status, body)
+		resp.Header.Set("Content-Type", "text/xml; charset=utf-8")
+		return resp
+	}
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/user",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			switch calls {
+			case 1:
+				return xmlResponse(http.StatusServiceUnavailable, ""), nil
+			case 2:
+				return xmlResponse(http.StatusOK, `<?xml version="1.0" encoding="utf-8"?><error><message>Rate limit exceeded.</message></error>`), nil
+			case 3:
+				resp := xmlResponse(http.StatusTooManyRequests, "")
+				resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+				return resp, nil
+			}
+			return xmlResponse(http.StatusOK, `<user id="1" name="gobgg"><yearregistered value="2015"/></user>`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		httpmock.NewStringResponder(http.StatusOK, `<?xml version="1.0" encoding="utf-8"?><error><message>Invalid id</message></error>`).
+			HeaderSet(http.Header{"Content-Type": {"text/xml"}}))
+	httpmock.RegisterResponder("POST", "https://boardgamegeek.com/login/api/v1",
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	ctx := context.Background()
+	var limiter countLimiter
+	policy := RetryPolicy{MaxRetries: 3, Delay: time.Millisecond}
+	bgg := NewBGGClient(SetLimiter(&limiter), SetRetryPolicy(policy))
+
+	// 503, the rate limit error and 429 are retried, each attempt goes through the limiter
+	user, err := bgg.GetUser(ctx, "gobgg")
+	require.NoError(t, err)
+	assert.Equal(t, "gobgg", user.UserName)
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, countLimiter(4), limiter)
+
+	// Out of retries
+	calls = 0
+	_, err = NewBGGClient(SetRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Millisecond})).GetUser(ctx, "gobgg")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Rate limit exceeded")
+	assert.Equal(t, 2, calls)
+
+	// Disabled by default
+	calls = 0
+	_, err = NewBGGClient().GetUser(ctx, "gobgg")
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// The other errors and the writes are not retried
+	_, err = bgg.GetThings(ctx, GetThingIDs(1))
+	require.Error(t, err)
+	require.Error(t, bgg.Login(ctx, "gobgg", "secret"))
+	info := httpmock.GetCallCountInfo()
+	assert.Equal(t, 1, info["GET https://boardgamegeek.com/xmlapi2/thing"])
+	assert.Equal(t, 1, info["POST https://boardgamegeek.com/login/api/v1"])
+
+	// The context is checked while waiting
+	calls = 0
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = NewBGGClient(SetRetryPolicy(RetryPolicy{MaxRetries: 1, Delay: time.Hour})).GetUser(cctx, "gobgg")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{Delay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Second, p.backoff(0, http.Header{}))
+	assert.Equal(t, 4*time.Second, p.backoff(2, http.Header{}))
+	assert.Equal(t, 5*time.Second, p.backoff(10, http.Header{}))
+	assert.Equal(t, 120*time.Second, p.backoff(0, http.Header{"Retry-After": {"120"}}))
+	assert.Equal(t, time.Second, p.backoff(0, http.Header{"Retry-After": {"soon"}}))
+
+	d, ok := retryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	assert.InDelta(t, time.Hour.Seconds(), d.Seconds(), 2)
+}
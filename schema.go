@@ -0,0 +1,381 @@
+package gobgg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The endpoints that are checked for the schema drift
+const (
+	EndpointThing      = "thing"
+	EndpointCollection = "collection"
+	EndpointPlays      = "plays"
+	EndpointSearch     = "search"
+	EndpointUser       = "user"
+	EndpointPerson     = "person"
+)
+
+// DriftKind is the kind of the schema drift
+type DriftKind string
+
+const (
+	// DriftUnknownElement is an element that the client does not read
+	DriftUnknownElement DriftKind = "unknown_element"
+	// DriftUnknownAttribute is an attribute that the client does not read
+	DriftUnknownAttribute DriftKind = "unknown_attribute"
+	// DriftMissingField is an element or attribute that the client expects but is not in the
+	// response, it is only reported when its parent element (or the parent of the attribute
+	// element) is in the response
+	DriftMissingField DriftKind = "missing_field"
+	// DriftUnknownValue is a value that the client does not handle, like a new name type
+	DriftUnknownValue DriftKind = "unknown_value"
+)
+
+// SchemaDrift is a difference between a BGG response and the format the client expects
+type SchemaDrift struct {
+	Endpoint string    `json:"endpoint"`
+	Kind     DriftKind `json:"kind"`
+	// Path is the slash separated element path, the attributes start with @, like
+	// items/item/name/@type
+	Path  string `json:"path"`
+	Value string `json:"value,omitempty"`
+}
+
+func (d SchemaDrift) String() string {
+	if d.Value != "" {
+		return fmt.Sprintf("%s: %s %s = %q", d.Endpoint, d.Kind, d.Path, d.Value)
+	}
+
+	return fmt.Sprintf("%s: %s %s", d.Endpoint, d.Kind, d.Path)
+}
+
+// SetSchemaDriftHandler enables the strict mode, every response of the XML API is compared with
+// the format the client expects, and the differences are reported to the handler (once for
+// each path in a response). The handler is called before the API function returns, and it
+// should be safe for concurrent use if the client is
+func SetSchemaDriftHandler(fn func(SchemaDrift)) OptionSetter {
+	return func(bgg *BGG) {
+		bgg.drift = fn
+	}
+}
+
+// schemaSpec is the expected fields and values of an endpoint
+type schemaSpec struct {
+	required []string
+	values   map[string][]string
+	// ignored are the elements that BGG sends but the client does not need
+	ignored []string
+}
+
+var schemaSpecs = map[string]schemaSpec{
+	EndpointThing: {
+		required: []string{
+			"items/item/@id",
+			"items/item/@type",
+			"items/item/name",
+			"items/item/yearpublished/@value",
+			"items/item/minplayers/@value",
+			"items/item/maxplayers/@value",
+			"items/item/playingtime/@value",
+			"items/item/statistics",
+			"items/item/statistics/ratings/usersrated/@value",
+			"items/item/statistics/ratings/average/@value",
+			"items/item/statistics/ratings/bayesaverage/@value",
+			"items/item/statistics/ratings/averageweight/@value",
+			"items/item/statistics/ratings/ranks/rank/@value",
+		},
+		values: map[string][]string{
+			"items/item/name/@type":                          {"primary", "alternate"},
+			"items/item/statistics/ratings/ranks/rank/@type": {string(RankKindSubtype), string(RankKindFamily)},
+		},
+		ignored: []string{"items/item/poll-summary"},
+	},
+	EndpointCollection: {
+		required: []string{
+			"items/item/@objectid",
+			"items/item/@subtype",
+			"items/item/name",
+			"items/item/status",
+			"items/item/status/@own",
+			"items/item/status/@lastmodified",
+			"items/item/numplays",
+			"items/item/stats/rating/@value",
+		},
+	},
+	EndpointPlays: {
+		required: []string{
+			"plays/play/@id",
+			"plays/play/@date",
+			"plays/play/@length",
+			"plays/play/item/@objectid",
+			"plays/play/item/@name",
+			"plays/play/players/player/@name",
+			"plays/play/players/player/@score",
+			"plays/play/players/player/@win",
+		},
+	},
+	EndpointSearch: {
+		required: []string{
+			"items/item/@id",
+			"items/item/@type",
+			"items/item/name",
+		},
+		values: map[string][]string{
+			"items/item/name/@type": {"primary", "alternate"},
+		},
+	},
+	EndpointUser: {
+		required: []string{
+			"user/@id",
+			"user/@name",
+			"user/yearregistered/@value",
+		},
+	},
+	EndpointPerson: {
+		// The image is not in the response for the people without a picture
+		required: []string{
+			"items/item/@id",
+		},
+	},
+}
+
+// schemaNode is the expected element, based on the struct that the response is decoded into
+type schemaNode struct {
+	children map[string]*schemaNode
+	attrs    map[string]bool
+	// leaf elements are decoded into a basic type, their children are not checked
+	leaf bool
+	// any accepts any child element
+	any bool
+}
+
+var schemaCache sync.Map
+
+func newSchemaNode() *schemaNode {
+	return &schemaNode{
+		children: make(map[string]*schemaNode),
+		attrs:    make(map[string]bool),
+	}
+}
+
+// schemaOf returns the root element name and the schema of the struct
+func schemaOf(typ reflect.Type) (string, *schemaNode) {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	if cached, ok := schemaCache.Load(typ); ok {
+		c := cached.(*cachedSchema)
+		return c.root, c.node
+	}
+
+	root := ""
+	if f, ok := typ.FieldByName("XMLName"); ok {
+		root, _, _ = strings.Cut(f.Tag.Get("xml"), ",")
+	}
+
+	node := newSchemaNode()
+	fillSchema(node, typ)
+	schemaCache.Store(typ, &cachedSchema{root: root, node: node})
+
+	return root, node
+}
+
+type cachedSchema struct {
+	root string
+	node *schemaNode
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func fillSchema(node *schemaNode, typ reflect.Type) {
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		node.leaf = true
+		return
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Name == "XMLName" {
+			continue
+		}
+
+		tag := f.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		switch {
+		case strings.Contains(flags, "attr"):
+			if name == "" {
+				name = f.Name
+			}
+			node.attrs[name] = true
+			continue
+		case strings.Contains(flags, "any"):
+			node.any = true
+			continue
+		case strings.Contains(flags, "chardata"), strings.Contains(flags, "innerxml"),
+			strings.Contains(flags, "comment"), strings.Contains(flags, "cdata"):
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		parent := node
+		parts := strings.Split(name, ">")
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := parent.children[part]
+			if !ok {
+				child = newSchemaNode()
+				parent.children[part] = child
+			}
+			parent = child
+		}
+
+		last := parts[len(parts)-1]
+		child, ok := parent.children[last]
+		if !ok {
+			child = newSchemaNode()
+			parent.children[last] = child
+		}
+		fillSchema(child, f.Type)
+	}
+}
+
+// checkSchema compares the XML document with the struct, and returns the differences
+func checkSchema(endpoint string, data []byte, in any) ([]SchemaDrift, error) {
+	root, schema := schemaOf(reflect.TypeOf(in))
+	spec := schemaSpecs[endpoint]
+
+	var (
+		drifts []SchemaDrift
+		seen   = make(map[string]bool)
+	)
+	report := func(kind DriftKind, p, value string) {
+		key := string(kind) + " " + p + " " + value
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		drifts = append(drifts, SchemaDrift{Endpoint: endpoint, Kind: kind, Path: p, Value: value})
+	}
+
+	type frame struct {
+		node *schemaNode
+		path string
+	}
+	var (
+		stack   []frame
+		present = make(map[string]bool)
+	)
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var (
+				node *schemaNode
+				p    = t.Name.Local
+			)
+			if len(stack) == 0 {
+				if root == "" || root == t.Name.Local {
+					node = schema
+				} else {
+					report(DriftUnknownElement, p, "")
+				}
+			} else {
+				parent := stack[len(stack)-1]
+				p = parent.path + "/" + t.Name.Local
+				if parent.node != nil && !parent.node.leaf && !parent.node.any {
+					node = parent.node.children[t.Name.Local]
+					if node == nil && !slices.Contains(spec.ignored, p) {
+						report(DriftUnknownElement, p, "")
+					}
+				}
+			}
+
+			present[p] = true
+			for _, attr := range t.Attr {
+				ap := p + "/@" + attr.Name.Local
+				present[ap] = true
+				if node != nil && !node.attrs[attr.Name.Local] && attr.Name.Space == "" {
+					report(DriftUnknownAttribute, ap, "")
+				}
+				if allowed, ok := spec.values[ap]; ok && !slices.Contains(allowed, attr.Value) {
+					report(DriftUnknownValue, ap, attr.Value)
+				}
+			}
+			stack = append(stack, frame{node: node, path: p})
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	for _, p := range spec.required {
+		parent := path.Dir(p)
+		if strings.HasPrefix(path.Base(p), "@") && !present[parent] {
+			if slices.Contains(spec.required, parent) {
+				// The missing element is reported already
+				continue
+			}
+			parent = path.Dir(parent)
+		}
+		if present[parent] && !present[p] {
+			report(DriftMissingField, p, "")
+		}
+	}
+
+	return drifts, nil
+}
+
+// decode decodes the response of the endpoint, and checks the schema in the strict mode
+func (bgg *BGG) decode(endpoint string, r io.Reader, in any) error {
+	if bgg.drift == nil {
+		return decode(r, in)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading data: %w", err)
+	}
+
+	if err := decode(bytes.NewReader(data), in); err != nil {
+		return err
+	}
+
+	drifts, err := checkSchema(endpoint, data, in)
+	if err != nil {
+		return fmt.Errorf("schema check failed: %w", err)
+	}
+	for i := range drifts {
+		bgg.drift(drifts[i])
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package gobgg
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const driftThing = `<?xml version="1.0" encoding="utf-8"?>
+<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+	<item type="boardgame" id="13" newattr="1">
+		<name type="primary" sortindex="1" value="CATAN"/>
+		<name type="translated" sortindex="1" value="Die Siedler von Catan"/>
+		<yearpublished value="1995"/>
+		<minplayers value="3"/>
+		<maxplayers value="4"/>
+		<playingtime value="120"/>
+		<poll-summary name="suggested_numplayers"/>
+		<weightpoll value="2.3"><vote/></weightpoll>
+		<statistics page="1">
+			<ratings>
+				<usersrated value="120000"/>
+				<average value="7.1"/>
+				<averageweight value="2.29"/>
+				<ranks><rank type="subtype" id="1" name="boardgame" value="600" bayesaverage="6.9"/></ranks>
+			</ratings>
+		</statistics>
+	</item>
+</items>`
+
+func TestSchemaDrift(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		httpmock.NewStringResponder(http.StatusOK, driftThing))
+
+	// Without the handler nothing changes
+	things, err := NewBGGClient().GetThings(context.Background(), GetThingIDs(13))
+	require.NoError(t, err)
+	require.Len(t, things, 1)
+
+	// With the handler the unknown name type is a drift, and it is not logged
+	var (
+		drifts []SchemaDrift
+		logs   bytes.Buffer
+	)
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	bgg := NewBGGClient(SetSchemaDriftHandler(func(d SchemaDrift) {
+		drifts = append(drifts, d)
+	}))
+	strict, err := bgg.GetThings(context.Background(), GetThingIDs(13))
+	require.NoError(t, err)
+	assert.Equal(t, things, strict)
+	assert.Empty(t, logs.String())
+
+	assert.ElementsMatch(t, []SchemaDrift{
+		{Endpoint: EndpointThing, Kind: DriftUnknownAttribute, Path: "items/item/@newattr"},
+		{Endpoint: EndpointThing, Kind: DriftUnknownValue, Path: "items/item/name/@type", Value: "translated"},
+		{Endpoint: EndpointThing, Kind: DriftUnknownElement, Path: "items/item/weightpoll"},
+		{Endpoint: EndpointThing, Kind: DriftMissingField, Path: "items/item/statistics/ratings/bayesaverage/@value"},
+	}, drifts)
+	assert.Equal(t, `thing: unknown_value items/item/name/@type = "translated"`, drifts[1].String())
+}
+
+func TestSchemaDriftCollection(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	data, err := os.ReadFile("testdata/collection.xml")
+	require.NoError(t, err)
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/collection",
+		httpmock.NewBytesResponder(http.StatusOK, data))
+
+	var drifts []SchemaDrift
+	bgg := NewBGGClient(SetSchemaDriftHandler(func(d SchemaDrift) {
+		drifts = append(drifts, d)
+	}))
+	items, err := bgg.GetCollection(context.Background(), "fzerorubigd")
+	require.NoError(t, err)
+	assert.NotEmpty(t, items)
+	assert.Empty(t, drifts, "the recorded response should match the client")
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/collection",
+		httpmock.NewStringResponder(http.StatusOK, `<?xml version="1.0" encoding="utf-8"?>
+<items totalitems="1"><item objecttype="thing" objectid="1" subtype="boardgame" collid="2"><name>One</name><state own="1"/><numplays>0</numplays></item></items>`))
+	_, err = bgg.GetCollection(context.Background(), "fzerorubigd")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []SchemaDrift{
+		{Endpoint: EndpointCollection, Kind: DriftUnknownElement, Path: "items/item/state"},
+		{Endpoint: EndpointCollection, Kind: DriftMissingField, Path: "items/item/status"},
+	}, drifts)
+}
+
+func TestSchemaDriftPerson(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/person",
+		httpmock.NewStringResponder(http.StatusOK, `<?xml version="1.0" encoding="utf-8"?>
+<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse"><item type="boardgamedesigner" id="2"></item></items>`))
+
+	var drifts []SchemaDrift
+	bgg := NewBGGClient(SetSchemaDriftHandler(func(d SchemaDrift) {
+		drifts = append(drifts, d)
+	}))
+	// A person without a picture has no image
+	img, err := bgg.PersonImage(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, img.Image)
+	assert.Empty(t, drifts)
+}
@@ -39,8 +39,9 @@ const searchPath = "xmlapi2/search"
 
 // SearchOption is used to handle func option ins earch api
 type SearchOption struct {
-	types []string
-	exact bool
+	types   []string
+	exact   bool
+	hydrate int
 }
 
 // SearchOptionSetter is used to handle the func option in search api
@@ -95,7 +96,7 @@ func (bgg *BGG) Search(ctx context.Context, query string, setter ...SearchOption
 	defer resp.Body.Close()
 
 	var result searchItems
-	if err = decode(resp.Body, &result); err != nil {
+	if err = bgg.decode(EndpointSearch, resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 
@@ -107,7 +108,7 @@ func (bgg *BGG) Search(ctx context.Context, query string, setter ...SearchOption
 			YearPublished: int(safeInt(result.Item[i].YearPublished.Value)),
 		}
 
-		ret[i].Name, ret[i].AlternateNames = nameStructToString(result.Item[i].Name)
+		ret[i].Name, ret[i].AlternateNames = bgg.nameStructToString(result.Item[i].Name)
 	}
 
 	return ret, nil
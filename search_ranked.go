@@ -0,0 +1,225 @@
+package gobgg
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MatchKind is how the search result name matches the query
+type MatchKind int
+
+const (
+	// MatchFuzzy is neither exact nor prefix match
+	MatchFuzzy MatchKind = iota
+	// MatchContains is when the name contains the query
+	MatchContains
+	// MatchPrefix is when the name starts with the query
+	MatchPrefix
+	// MatchExact is when the name is the query
+	MatchExact
+)
+
+func (m MatchKind) String() string {
+	switch m {
+	case MatchFuzzy:
+		return "fuzzy"
+	case MatchContains:
+		return "contains"
+	case MatchPrefix:
+		return "prefix"
+	case MatchExact:
+		return "exact"
+	}
+
+	return "unknown"
+}
+
+// RankedSearchResult is the search result with the relevance and (optionally) the thing details
+type RankedSearchResult struct {
+	SearchResult
+	// Types are all the types that this item is returned with
+	Types []ItemType
+	Match MatchKind
+	// Similarity is between 0 and 1, based on the edit distance of the best matching name
+	Similarity float64
+	// Hydrated is true when the thing details are fetched
+	Hydrated     bool
+	Thumbnail    string
+	Rank         int // Zero means not ranked
+	UsersRated   int
+	AverageRate  float64
+	BayesAverage float64
+}
+
+// SearchHydrate fetches the thing details (thumbnail, rank and rating) for the top n results in
+// SearchRanked, it is also used to sort the results with the same match by popularity
+func SearchHydrate(n int) SearchOptionSetter {
+	return func(opt *SearchOption) {
+		opt.hydrate = n
+	}
+}
+
+func normalizeName(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space && b.Len() > 0 {
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func matchName(query, name string) (MatchKind, float64) {
+	name = normalizeName(name)
+	sim := similarity(query, name)
+	switch {
+	case name == query:
+		return MatchExact, sim
+	case strings.HasPrefix(name, query):
+		return MatchPrefix, sim
+	case strings.Contains(name, query):
+		return MatchContains, sim
+	}
+
+	return MatchFuzzy, sim
+}
+
+func rankSearchResults(query string, items []SearchResult) []RankedSearchResult {
+	query = normalizeName(query)
+	byID := make(map[int64]int)
+	var result []RankedSearchResult
+	for i := range items {
+		if idx, ok := byID[items[i].ID]; ok {
+			result[idx].Types = append(result[idx].Types, items[i].Type)
+			continue
+		}
+
+		r := RankedSearchResult{
+			SearchResult: items[i],
+			Types:        []ItemType{items[i].Type},
+		}
+		names := append([]string{items[i].Name}, items[i].AlternateNames...)
+		for _, name := range names {
+			m, sim := matchName(query, name)
+			if m > r.Match || (m == r.Match && sim > r.Similarity) {
+				r.Match, r.Similarity = m, sim
+			}
+		}
+
+		byID[items[i].ID] = len(result)
+		result = append(result, r)
+	}
+
+	sortRanked(result)
+	return result
+}
+
+func sortRanked(result []RankedSearchResult) {
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Match != result[j].Match {
+			return result[i].Match > result[j].Match
+		}
+		if result[i].UsersRated != result[j].UsersRated {
+			return result[i].UsersRated > result[j].UsersRated
+		}
+		return result[i].Similarity > result[j].Similarity
+	})
+}
+
+// SearchRanked is the enhanced search, it removes the duplicate items (when an item is returned for
+// more than one type), and sorts the results by the relevance to the query, exact match first, then
+// prefix match, contains and then the rest by their edit distance.
+// With SearchHydrate it fetches the details of the top results using GetThings.
+func (bgg *BGG) SearchRanked(ctx context.Context, query string, setter ...SearchOptionSetter) ([]RankedSearchResult, error) {
+	opt := SearchOption{}
+	for i := range setter {
+		setter[i](&opt)
+	}
+
+	items, err := bgg.Search(ctx, query, setter...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := rankSearchResults(query, items)
+	n := min(opt.hydrate, len(result))
+	if n <= 0 {
+		return result, nil
+	}
+
+	for start := 0; start < n; start += 20 {
+		end := min(start+20, n)
+		ids := make([]int64, 0, end-start)
+		for i := start; i < end; i++ {
+			ids = append(ids, result[i].ID)
+		}
+
+		things, err := bgg.GetThings(ctx, GetThingIDs(ids...))
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[int64]*ThingResult, len(things))
+		for i := range things {
+			byID[things[i].ID] = &things[i]
+		}
+
+		for i := start; i < end; i++ {
+			th, ok := byID[result[i].ID]
+			if !ok {
+				continue
+			}
+			result[i].Hydrated = true
+			result[i].Thumbnail = th.Thumbnail
+			result[i].Rank = th.RankTotal
+			result[i].UsersRated = th.UsersRated
+			result[i].AverageRate = th.AverageRate
+			result[i].BayesAverage = th.BayesAverage
+		}
+	}
+
+	sortRanked(result[:n])
+	return result, nil
+}
@@ -74,3 +74,58 @@ func TestSearch(t *testing.T) {
 	assert.Equal(t, 2018, items[0].YearPublished)
 	assert.Equal(t, BoardGameType, items[0].Type)
 }
+
+func TestSearchRanked(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+searchPath,
+		httpmock.NewStringResponder(200, `<?xml version="1.0" encoding="utf-8"?>
+		<items total="6">
+			<item type="boardgame" id="3"><name type="primary" value="Catan: Cities &amp; Knights"/></item>
+			<item type="boardgame" id="4"><name type="primary" value="Kattan"/></item>
+			<item type="boardgame" id="13"><name type="primary" value="CATAN"/><name type="alternate" value="Die Siedler von Catan"/></item>
+			<item type="boardgameexpansion" id="13"><name type="primary" value="CATAN"/></item>
+			<item type="boardgame" id="5"><name type="primary" value="Star Trek: Catan"/></item>
+			<item type="boardgame" id="6"><name type="primary" value="Catan"/></item>
+		</items>`))
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+thingPath,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "13,6", req.URL.Query().Get("id"))
+			return httpmock.NewStringResponse(200, `<?xml version="1.0" encoding="utf-8"?><items>
+			<item type="boardgame" id="6"><thumbnail>t6</thumbnail><statistics><ratings><usersrated value="10"/></ratings></statistics></item>
+			<item type="boardgame" id="13"><thumbnail>t13</thumbnail><statistics><ratings>
+				<usersrated value="120000"/><average value="7.1"/><bayesaverage value="6.9"/>
+				<ranks><rank type="subtype" id="1" name="boardgame" value="550"/></ranks>
+			</ratings></statistics></item>
+			</items>`), nil
+		})
+
+	bgg := NewBGGClient()
+	items, err := bgg.SearchRanked(context.Background(), "catan",
+		SearchTypes(BoardGameType, BoardGameExpansionType))
+	require.NoError(t, err)
+	require.Len(t, items, 5)
+	ids := make([]int64, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+	assert.Equal(t, []int64{13, 6, 3, 5, 4}, ids)
+	assert.Equal(t, []ItemType{BoardGameType, BoardGameExpansionType}, items[0].Types)
+	assert.Equal(t, MatchExact, items[0].Match)
+	assert.Equal(t, MatchPrefix, items[2].Match)
+	assert.Equal(t, MatchContains, items[3].Match)
+	assert.Equal(t, MatchFuzzy, items[4].Match)
+	assert.False(t, items[0].Hydrated)
+
+	items, err = bgg.SearchRanked(context.Background(), "catan", SearchHydrate(2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), items[0].ID)
+	assert.True(t, items[0].Hydrated)
+	assert.Equal(t, "t13", items[0].Thumbnail)
+	assert.Equal(t, 550, items[0].Rank)
+	assert.Equal(t, 7.1, items[0].AverageRate)
+	assert.True(t, items[1].Hydrated)
+	assert.False(t, items[2].Hydrated)
+}
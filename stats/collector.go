@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// defaultBatchSize is the maximum number of ids in a single thing request
+const defaultBatchSize = 20
+
+// Collector fetches the statistics of the watchlist and saves them in the store
+type Collector struct {
+	bgg       *gobgg.BGG
+	store     Store
+	ids       []int64
+	batchSize int
+	breakDown bool
+	onError   func(error)
+	now       func() time.Time
+}
+
+// OptionSetter is the option setter for the collector
+type OptionSetter func(*Collector)
+
+// Watch adds the games to the watchlist
+func Watch(ids ...int64) OptionSetter {
+	return func(c *Collector) {
+		c.ids = append(c.ids, ids...)
+	}
+}
+
+// BatchSize sets the number of games in each GetThings call, default is 20
+func BatchSize(n int) OptionSetter {
+	return func(c *Collector) {
+		c.batchSize = n
+	}
+}
+
+// BreakDown fetches the rating break down of each game too, it is one extra request per game
+func BreakDown(fetch bool) OptionSetter {
+	return func(c *Collector) {
+		c.breakDown = fetch
+	}
+}
+
+// OnError sets a function that is called with the failed collects in Run, the next collect is
+// on the next tick
+func OnError(fn func(error)) OptionSetter {
+	return func(c *Collector) {
+		c.onError = fn
+	}
+}
+
+// New creates a new collector, all the requests go through the client, so its limiter is
+// respected
+func New(bgg *gobgg.BGG, store Store, opts ...OptionSetter) *Collector {
+	c := &Collector{
+		bgg:       bgg,
+		store:     store,
+		batchSize: defaultBatchSize,
+		now:       time.Now,
+	}
+
+	for i := range opts {
+		opts[i](c)
+	}
+
+	if c.batchSize <= 0 {
+		c.batchSize = defaultBatchSize
+	}
+
+	return c
+}
+
+func fromThing(thing *gobgg.ThingResult, now time.Time) Snapshot {
+	return Snapshot{
+		ID:            thing.ID,
+		Name:          thing.Name,
+		Time:          now,
+		UsersRated:    thing.UsersRated,
+		Average:       thing.AverageRate,
+		BayesAverage:  thing.BayesAverage,
+		Rank:          thing.RankTotal,
+		Ranks:         thing.Ranks,
+		UsersOwned:    thing.UsersOwned,
+		UsersTrading:  thing.UsersTrading,
+		UsersWanting:  thing.UsersWanting,
+		UsersWishing:  thing.UsersWishing,
+		NumComments:   thing.NumComments,
+		NumWeight:     thing.NumWeight,
+		AverageWeight: thing.AverageWeight,
+	}
+}
+
+// Collect takes one snapshot of all the games in the watchlist and saves them
+func (c *Collector) Collect(ctx context.Context) ([]Snapshot, error) {
+	now := c.now()
+	var result []Snapshot
+	for start := 0; start < len(c.ids); start += c.batchSize {
+		end := min(start+c.batchSize, len(c.ids))
+		things, err := c.bgg.GetThings(ctx, gobgg.GetThingIDs(c.ids[start:end]...))
+		if err != nil {
+			return nil, fmt.Errorf("get things failed: %w", err)
+		}
+
+		batch := make([]Snapshot, 0, len(things))
+		for i := range things {
+			snap := fromThing(&things[i], now)
+			if c.breakDown {
+				rb, err := c.bgg.GetRankBreakDown(ctx, snap.ID)
+				if err != nil {
+					return nil, fmt.Errorf("get rank break down of %d failed: %w", snap.ID, err)
+				}
+				snap.BreakDown = &rb
+			}
+			batch = append(batch, snap)
+		}
+
+		if err := c.store.Save(ctx, batch...); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSave, err)
+		}
+		result = append(result, batch...)
+	}
+
+	return result, nil
+}
+
+// Run collects the snapshots every interval until the context is canceled, the first one is
+// taken immediately. A failed collect is reported to the OnError function and retried on the
+// next tick, only a store failure stops it
+func (c *Collector) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Collect(ctx); err != nil {
+			if errors.Is(err, ErrSave) {
+				return err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if c.onError != nil {
+				c.onError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Delta is the change of a game statistics between two snapshots
+type Delta struct {
+	ID   int64     `json:"id"`
+	Name string    `json:"name"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	UsersRated   int     `json:"users_rated"`
+	Average      float64 `json:"average"`
+	BayesAverage float64 `json:"bayes_average"`
+	// Rank is the change in the overall rank, negative means the game is moved up. It is zero
+	// when the game is not ranked in one of the snapshots
+	Rank          int     `json:"rank"`
+	UsersOwned    int     `json:"users_owned"`
+	UsersWishing  int     `json:"users_wishing"`
+	UsersWanting  int     `json:"users_wanting"`
+	NumWeight     int     `json:"num_weight"`
+	AverageWeight float64 `json:"average_weight"`
+}
+
+// Diff returns the change from the before snapshot to the after snapshot
+func Diff(before, after Snapshot) Delta {
+	d := Delta{
+		ID:            after.ID,
+		Name:          after.Name,
+		From:          before.Time,
+		To:            after.Time,
+		UsersRated:    after.UsersRated - before.UsersRated,
+		Average:       after.Average - before.Average,
+		BayesAverage:  after.BayesAverage - before.BayesAverage,
+		UsersOwned:    after.UsersOwned - before.UsersOwned,
+		UsersWishing:  after.UsersWishing - before.UsersWishing,
+		UsersWanting:  after.UsersWanting - before.UsersWanting,
+		NumWeight:     after.NumWeight - before.NumWeight,
+		AverageWeight: after.AverageWeight - before.AverageWeight,
+	}
+	if before.Rank > 0 && after.Rank > 0 {
+		d.Rank = after.Rank - before.Rank
+	}
+
+	return d
+}
+
+// Since returns the change of the game since the date, the base is the last snapshot at or
+// before the date (or the first one, if the game was added later) and the target is the latest
+// snapshot
+func Since(ctx context.Context, store Store, id int64, since time.Time) (Delta, error) {
+	history, err := store.History(ctx, id, time.Time{}, time.Time{})
+	if err != nil {
+		return Delta{}, err
+	}
+
+	if len(history) == 0 {
+		return Delta{}, fmt.Errorf("%w: %d", ErrNoSnapshot, id)
+	}
+
+	base := history[0]
+	for i := range history {
+		if history[i].Time.After(since) {
+			break
+		}
+		base = history[i]
+	}
+
+	return Diff(base, history[len(history)-1]), nil
+}
+
+// AllSince returns the change of all the games in the store since the date
+func AllSince(ctx context.Context, store Store, since time.Time) ([]Delta, error) {
+	ids, err := store.IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Delta, 0, len(ids))
+	for _, id := range ids {
+		d, err := Since(ctx, store, id, since)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, nil
+}
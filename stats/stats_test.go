@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	day := 0
+	var batches []string
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		func(req *http.Request) (*http.Response, error) {
+			ids := req.URL.Query().Get("id")
+			batches = append(batches, ids)
+			body := `<?xml version="1.0" encoding="utf-8"?><items>`
+			for _, id := range strings.Split(ids, ",") {
+				body += fmt.Sprintf(`<item type="boardgame" id="%[1]s"><name type="primary" value="Game %[1]s"/>
+				<statistics page="1"><ratings>
+					<usersrated value="%[2]d" /><average value="7.5" /><bayesaverage value="6.5" />
+					<ranks><rank type="subtype" id="1" name="boardgame" friendlyname="Board Game Rank" value="%[3]d" bayesaverage="6.5" /></ranks>
+					<owned value="%[4]d" /><wishing value="%[2]d" /><numweights value="10" /><averageweight value="2.5" />
+				</ratings></statistics></item>`, id, 100+day*10, 50-day, 1000+day)
+			}
+			return httpmock.NewStringResponse(200, body+"</items>"), nil
+		})
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/api/collectionstatsgraph",
+		httpmock.NewStringResponder(200, `{"data":{"rows":[{"c":[{"v":10},{"v":5}]}]}}`))
+
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(gobgg.NewBGGClient(), store, Watch(1, 2, 3), BatchSize(2), BreakDown(true))
+	c.now = func() time.Time { return start.AddDate(0, 0, day) }
+
+	for day = 0; day < 3; day++ {
+		snaps, err := c.Collect(ctx)
+		require.NoError(t, err)
+		require.Len(t, snaps, 3)
+	}
+	assert.Equal(t, []string{"1,2", "3", "1,2", "3", "1,2", "3"}, batches)
+
+	ids, err := store.IDs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, ids)
+
+	history, err := store.History(ctx, 2, start.AddDate(0, 0, 1), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "Game 2", history[0].Name)
+	assert.Equal(t, 49, history[0].Rank)
+	assert.Equal(t, 1001, history[0].UsersOwned)
+	require.NotNil(t, history[0].BreakDown)
+	assert.Equal(t, int64(5), history[0].BreakDown[9])
+
+	d, err := Since(ctx, store, 2, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, start, d.From)
+	assert.Equal(t, start.AddDate(0, 0, 2), d.To)
+	assert.Equal(t, 20, d.UsersRated)
+	assert.Equal(t, 20, d.UsersWishing)
+	assert.Equal(t, -2, d.Rank)
+	assert.Equal(t, 2, d.UsersOwned)
+	assert.Zero(t, d.Average)
+
+	// Before the first snapshot, the first one is the base
+	all, err := AllSince(ctx, store, start.AddDate(0, 0, -10))
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, 20, all[0].UsersRated)
+
+	_, err = Since(ctx, store, 4, start)
+	require.ErrorIs(t, err, ErrNoSnapshot)
+}
+
+func TestDiffNotRanked(t *testing.T) {
+	d := Diff(Snapshot{Rank: 0, UsersRated: 10}, Snapshot{Rank: 100, UsersRated: 30})
+	assert.Zero(t, d.Rank)
+	assert.Equal(t, 20, d.UsersRated)
+}
+
+type failingStore struct {
+	Store
+}
+
+func (failingStore) Save(context.Context, ...Snapshot) error {
+	return fmt.Errorf("disk is full")
+}
+
+func TestCollectorRun(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/xmlapi2/thing",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+			}
+			return httpmock.NewStringResponse(200, `<?xml version="1.0" encoding="utf-8"?><items>
+				<item type="boardgame" id="1"><name type="primary" value="Game 1"/></item></items>`), nil
+		})
+
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	// The failed collect does not stop the loop
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var errs []error
+	c := New(gobgg.NewBGGClient(), store, Watch(1), OnError(func(err error) {
+		errs = append(errs, err)
+		if len(errs) > 1 {
+			return
+		}
+		go func() {
+			for {
+				ids, _ := store.IDs(ctx)
+				if len(ids) > 0 {
+					cancel()
+					return
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}))
+	require.ErrorIs(t, c.Run(ctx, time.Millisecond), context.Canceled)
+	require.Len(t, errs, 1)
+	assert.GreaterOrEqual(t, calls, 2)
+
+	// The store failure stops it
+	err = New(gobgg.NewBGGClient(), failingStore{store}, Watch(1)).Run(context.Background(), time.Millisecond)
+	require.ErrorIs(t, err, ErrSave)
+}
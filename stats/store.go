@@ -0,0 +1,177 @@
+// Package stats collects the thing statistics (rating, rank, owned, wishing, weight...) of a
+// watchlist of games over time, and answers the "what changed since" queries
+package stats
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fzerorubigd/gobgg"
+)
+
+// ErrNoSnapshot is returned when there is no snapshot for the game
+var ErrNoSnapshot = errors.New("no snapshot")
+
+// ErrSave is returned when the store can not save the snapshots
+var ErrSave = errors.New("save snapshots failed")
+
+// Snapshot is the statistics of a game at a point in time
+type Snapshot struct {
+	ID   int64     `json:"id"`
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+
+	UsersRated   int     `json:"users_rated"`
+	Average      float64 `json:"average"`
+	BayesAverage float64 `json:"bayes_average"`
+	// Rank is the overall rank, zero means not ranked
+	Rank  int         `json:"rank,omitempty"`
+	Ranks gobgg.Ranks `json:"ranks,omitempty"`
+
+	UsersOwned    int     `json:"users_owned"`
+	UsersTrading  int     `json:"users_trading"`
+	UsersWanting  int     `json:"users_wanting"`
+	UsersWishing  int     `json:"users_wishing"`
+	NumComments   int     `json:"num_comments"`
+	NumWeight     int     `json:"num_weight"`
+	AverageWeight float64 `json:"average_weight"`
+
+	// BreakDown is only available when the collector is asked for it
+	BreakDown *gobgg.RankBreakDown `json:"break_down,omitempty"`
+}
+
+// Store keeps the snapshots
+type Store interface {
+	// Save adds the snapshots to the store
+	Save(ctx context.Context, snapshots ...Snapshot) error
+	// History returns the snapshots of a game between from and to (inclusive), sorted by time,
+	// zero from or to means no limit
+	History(ctx context.Context, id int64, from, to time.Time) ([]Snapshot, error)
+	// IDs returns the game ids in the store
+	IDs(ctx context.Context) ([]int64, error)
+}
+
+// FileStore is a flat-file store, each game is a JSON lines file in the directory
+type FileStore struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// NewFileStore creates the store in the directory, the directory is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create directory failed: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (fs *FileStore) path(id int64) string {
+	return filepath.Join(fs.dir, fmt.Sprintf("%d.jsonl", id))
+}
+
+// Save appends the snapshots to the game files
+func (fs *FileStore) Save(_ context.Context, snapshots ...Snapshot) error {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	for i := range snapshots {
+		data, err := json.Marshal(snapshots[i])
+		if err != nil {
+			return fmt.Errorf("encoding snapshot failed: %w", err)
+		}
+
+		f, err := os.OpenFile(fs.path(snapshots[i].ID), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("open file failed: %w", err)
+		}
+
+		_, err = f.Write(append(data, '\n'))
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+		if err != nil {
+			return fmt.Errorf("write snapshot failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// History reads the game file and returns the snapshots in the range
+func (fs *FileStore) History(_ context.Context, id int64, from, to time.Time) ([]Snapshot, error) {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	f, err := os.Open(fs.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open file failed: %w", err)
+	}
+	defer f.Close()
+
+	var result []Snapshot
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var snap Snapshot
+		if err := json.Unmarshal(line, &snap); err != nil {
+			return nil, fmt.Errorf("decoding snapshot failed: %w", err)
+		}
+		if !from.IsZero() && snap.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && snap.Time.After(to) {
+			continue
+		}
+		result = append(result, snap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read file failed: %w", err)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result, nil
+}
+
+// IDs returns the ids of the games with a file in the directory
+func (fs *FileStore) IDs(_ context.Context) ([]int64, error) {
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory failed: %w", err)
+	}
+
+	var result []int64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".jsonl")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+			result = append(result, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result, nil
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
@@ -159,6 +160,27 @@ type CollectionItem struct {
 	Thumbnail     string   `json:"thumbnail,omitempty"`
 	Image         string   `json:"image,omitempty"`
 
+	OriginalName string `json:"original_name,omitempty"`
+
+	// Rating is the user rating, it is only available when the stats are requested,
+	// zero means not rated
+	Rating          float64   `json:"rating,omitempty"`
+	NumPlays        int       `json:"num_plays,omitempty"`
+	Comment         string    `json:"comment,omitempty"`
+	WishListComment string    `json:"wish_list_comment,omitempty"`
+	LastModified    time.Time `json:"last_modified,omitempty"`
+
+	// These are only available when the stats are requested
+	MinPlayers   int     `json:"min_players,omitempty"`
+	MaxPlayers   int     `json:"max_players,omitempty"`
+	PlayingTime  int     `json:"playing_time,omitempty"`
+	MinPlayTime  int     `json:"min_play_time,omitempty"`
+	MaxPlayTime  int     `json:"max_play_time,omitempty"`
+	NumOwned     int     `json:"num_owned,omitempty"`
+	Average      float64 `json:"average,omitempty"`
+	BayesAverage float64 `json:"bayes_average,omitempty"`
+	Ranks        Ranks   `json:"ranks,omitempty"`
+
 	CollectionStatus []string `json:"collection_status,omitempty"`
 }
 
@@ -216,8 +238,11 @@ type ThingResult struct {
 	NumWeight     int     `json:"num_weight,omitempty"`
 	AverageWeight float64 `json:"average_weight,omitempty"`
 
+	// RankTotal is the overall rank in the item subtype, zero means not ranked
 	RankTotal int                   `json:"rank_total,omitempty"`
 	Family    map[string]FamilyRank `json:"family,omitempty"`
+	// Ranks is all the rank entries, including the not ranked ones
+	Ranks Ranks `json:"ranks,omitempty"`
 
 	BGGURL string
 }
@@ -297,7 +322,7 @@ func (bgg *BGG) GetThings(ctx context.Context, setters ...GetOptionSetter) ([]Th
 	}
 	defer resp.Body.Close()
 	var result thingItems
-	if err = decode(resp.Body, &result); err != nil {
+	if err = bgg.decode(EndpointThing, resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 
@@ -335,24 +360,21 @@ func (bgg *BGG) GetThings(ctx context.Context, setters ...GetOptionSetter) ([]Th
 			BGGURL:               bgg.getFullURL(ctx, result.Item[i].ID, opt.fullURL),
 		}
 
-		for _, r := range result.Item[i].Statistics.Ratings.Ranks.Rank {
-			if r.Type == "subtype" && r.Name == "boardgame" {
-				ret[i].RankTotal = int(safeInt(r.Value))
-				continue
-			}
-
-			if r.Type == "family" {
-				ret[i].Family[r.Name] = FamilyRank{
-					ID:           safeInt(r.ID),
-					Name:         r.Name,
-					FriendlyName: r.Friendlyname,
-					Rank:         int(safeInt(r.Value)),
-					BayesAverage: safeFloat64(r.Bayesaverage),
-				}
+		ret[i].Ranks = parseRanks(result.Item[i].Statistics.Ratings.Ranks.Rank)
+		if r, ok := ret[i].Ranks.Subtype(); ok {
+			ret[i].RankTotal = r.Value
+		}
+		for _, r := range ret[i].Ranks.Families() {
+			ret[i].Family[r.Name] = FamilyRank{
+				ID:           r.ID,
+				Name:         r.Name,
+				FriendlyName: r.FriendlyName,
+				Rank:         r.Value,
+				BayesAverage: r.BayesAverage,
 			}
 		}
 
-		ret[i].Name, ret[i].AlternateNames = nameStructToString(result.Item[i].Name)
+		ret[i].Name, ret[i].AlternateNames = bgg.nameStructToString(result.Item[i].Name)
 		ret[i].Links = linksMap(result.Item[i].Link)
 	}
 
@@ -395,6 +417,34 @@ func (rb RankBreakDown) BayesianAverage(added int64) float64 {
 	return m / float64(total)
 }
 
+// WeightBreakDown is the number of weight votes for each weight from 1 to 5
+type WeightBreakDown [5]int64
+
+// Total is the number of the weight votes
+func (wb WeightBreakDown) Total() int64 {
+	var total int64
+	for i := range wb {
+		total += wb[i]
+	}
+
+	return total
+}
+
+// Average is the average weight, zero if there is no vote
+func (wb WeightBreakDown) Average() float64 {
+	total := wb.Total()
+	if total == 0 {
+		return 0
+	}
+
+	var sum float64
+	for i := range wb {
+		sum += float64(i+1) * float64(wb[i])
+	}
+
+	return sum / float64(total)
+}
+
 type rankBreakDownResponse struct {
 	Type       string         `json:"type"`
 	Options    map[string]any `json:"options"`
@@ -410,47 +460,150 @@ type rankBreakDownResponse struct {
 	} `json:"data"`
 }
 
-func (bgg *BGG) GetRankBreakDown(ctx context.Context, gameID int64) (RankBreakDown, error) {
+// RankBreakDownObjectType is the object type for the GetRankBreakDown
+type RankBreakDownObjectType string
+
+const (
+	// RankBreakDownThing is for the games
+	RankBreakDownThing RankBreakDownObjectType = "thing"
+	// RankBreakDownFamily is for the families
+	RankBreakDownFamily RankBreakDownObjectType = "family"
+)
+
+// RankBreakDownRatingType is the rating type for the GetRankBreakDown
+type RankBreakDownRatingType string
+
+const (
+	// RankBreakDownRating is the user ratings, it is the default
+	RankBreakDownRating RankBreakDownRatingType = ""
+	// RankBreakDownWeight is the weight votes, from 1 to 5, see GetWeightBreakDown
+	RankBreakDownWeight RankBreakDownRatingType = "weight"
+)
+
+// RankBreakDownOption is the option for the GetRankBreakDown
+type RankBreakDownOption struct {
+	objectType RankBreakDownObjectType
+	ratingType RankBreakDownRatingType
+}
+
+// RankBreakDownOptionSetter is the option setter for the GetRankBreakDown
+type RankBreakDownOptionSetter func(*RankBreakDownOption)
+
+// RankBreakDownType sets the object type, default is thing
+func RankBreakDownType(typ RankBreakDownObjectType) RankBreakDownOptionSetter {
+	return func(opt *RankBreakDownOption) {
+		opt.objectType = typ
+	}
+}
+
+// RankBreakDownRatings sets the rating type, default is the user ratings
+func RankBreakDownRatings(typ RankBreakDownRatingType) RankBreakDownOptionSetter {
+	return func(opt *RankBreakDownOption) {
+		opt.ratingType = typ
+	}
+}
+
+// GetRankBreakDown returns the number of votes for each rating from 1 to 10, the weight votes
+// are from 1 to 5 so they are only available with GetWeightBreakDown
+func (bgg *BGG) GetRankBreakDown(ctx context.Context, gameID int64, setters ...RankBreakDownOptionSetter) (RankBreakDown, error) {
+	opt := RankBreakDownOption{
+		objectType: RankBreakDownThing,
+	}
+	for i := range setters {
+		setters[i](&opt)
+	}
+
+	rbd := RankBreakDown{}
+	if opt.ratingType == RankBreakDownWeight {
+		return rbd, fmt.Errorf("the weight votes are from 1 to 5, use GetWeightBreakDown")
+	}
+
+	votes, err := bgg.rankBreakDown(ctx, gameID, opt)
+	if err != nil {
+		return rbd, err
+	}
+
+	for num, val := range votes {
+		if num <= 10 && num > 0 {
+			rbd[num-1] = val
+		}
+	}
+
+	return rbd, nil
+}
+
+// GetWeightBreakDown returns the number of weight votes for each weight from 1 to 5, the rating
+// type option is ignored
+func (bgg *BGG) GetWeightBreakDown(ctx context.Context, gameID int64, setters ...RankBreakDownOptionSetter) (WeightBreakDown, error) {
+	opt := RankBreakDownOption{
+		objectType: RankBreakDownThing,
+	}
+	for i := range setters {
+		setters[i](&opt)
+	}
+	opt.ratingType = RankBreakDownWeight
+
+	wbd := WeightBreakDown{}
+	votes, err := bgg.rankBreakDown(ctx, gameID, opt)
+	if err != nil {
+		return wbd, err
+	}
+
+	for num, val := range votes {
+		if num <= 5 && num > 0 {
+			wbd[num-1] = val
+		}
+	}
+
+	return wbd, nil
+}
+
+// rankBreakDown returns the votes of each row in the stats graph
+func (bgg *BGG) rankBreakDown(ctx context.Context, gameID int64, opt RankBreakDownOption) (map[int64]int64, error) {
 	args := map[string]string{
 		"objectid":   fmt.Sprint(gameID),
-		"objecttype": "thing",
+		"objecttype": string(opt.objectType),
 		"type":       "BarChart",
 	}
-	rbd := RankBreakDown{}
+	if opt.ratingType != RankBreakDownRating {
+		args["ratingtype"] = string(opt.ratingType)
+	}
 	u := bgg.buildURL(rankPath, args)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		return rbd, fmt.Errorf("create request failed: %w", err)
+		return nil, fmt.Errorf("create request failed: %w", err)
 	}
 
 	resp, err := bgg.do(req)
 	if err != nil {
-		return rbd, fmt.Errorf("http call failed: %w", err)
+		return nil, fmt.Errorf("http call failed: %w", err)
 	}
 	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status: %q", resp.Status)
+	}
+
 	var result rankBreakDownResponse
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return rbd, fmt.Errorf("read data failed: %w", err)
+		return nil, fmt.Errorf("read data failed: %w", err)
 	}
 
 	if err = json.Unmarshal(data, &result); err != nil {
-		return rbd, fmt.Errorf("JSON decoding failed: %w", err)
+		return nil, fmt.Errorf("JSON decoding failed: %w", err)
 	}
 
+	votes := make(map[int64]int64, len(result.Data.Rows))
 	for _, row := range result.Data.Rows {
 		if len(row.C) < 2 {
-			return rbd, fmt.Errorf("[RESPONSE] invalid row data")
+			return nil, fmt.Errorf("[RESPONSE] invalid row data")
 		}
 
-		num := safeIntInterface(row.C[0].V)
-		val := safeIntInterface(row.C[1].V)
-		if num <= 10 && num > 0 {
-			rbd[num-1] = val
-		}
+		votes[safeIntInterface(row.C[0].V)] = safeIntInterface(row.C[1].V)
 	}
 
-	return rbd, nil
+	return votes, nil
 }
 
 func (bgg *BGG) getFullURL(ctx context.Context, gameID int64, validate bool) string {
@@ -108,3 +108,53 @@ func TestGetThing(t *testing.T) {
 		})
 	}
 }
+
+func TestGetThingRanks(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://boardgamegeek.com/"+thingPath,
+		httpmock.NewStringResponder(200, `<?xml version="1.0" encoding="utf-8"?>
+		<items termsofuse="https://boardgamegeek.com/xmlapi/termsofuse">
+			<item type="rpgitem" id="10">
+				<name type="primary" value="RPG Book"/>
+				<statistics page="1">
+					<ratings>
+						<ranks>
+							<rank type="subtype" id="8" name="rpgitem" friendlyname="RPG Item Rank" value="42" bayesaverage="7.1" />
+						</ranks>
+					</ratings>
+				</statistics>
+			</item>
+			<item type="boardgame" id="11">
+				<name type="primary" value="New Game"/>
+				<statistics page="1">
+					<ratings>
+						<ranks>
+							<rank type="subtype" id="1" name="boardgame" friendlyname="Board Game Rank" value="Not Ranked" bayesaverage="Not Ranked" />
+							<rank type="family" id="5497" name="strategygames" friendlyname="Strategy Game Rank" value="Not Ranked" bayesaverage="Not Ranked" />
+						</ranks>
+					</ratings>
+				</statistics>
+			</item>
+		</items>`))
+
+	bgg := NewBGGClient()
+	items, err := bgg.GetThings(context.Background(), GetThingIDs(10, 11))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, 42, items[0].RankTotal)
+	rank, ok := items[0].RankIn("rpgitem")
+	require.True(t, ok)
+	assert.Equal(t, Rank{Kind: RankKindSubtype, ID: 8, Name: "rpgitem", FriendlyName: "RPG Item Rank", Ranked: true, Value: 42, BayesAverage: 7.1}, rank)
+
+	assert.Zero(t, items[1].RankTotal)
+	rank, ok = items[1].RankIn("strategygames")
+	require.True(t, ok)
+	assert.False(t, rank.Ranked)
+	assert.Equal(t, RankKindFamily, rank.Kind)
+	_, ok = items[1].RankIn("partygames")
+	assert.False(t, ok)
+	assert.Len(t, items[1].Ranks.Families(), 1)
+}
@@ -0,0 +1,144 @@
+package gobgg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TrendList is one of the trend lists on the site
+type TrendList string
+
+const (
+	// TrendListBestSellers is the BestSellers list, it supports only the week interval
+	TrendListBestSellers TrendList = "bestsellers"
+	// TrendListMostPlays is the MostPlays list
+	TrendListMostPlays TrendList = "plays"
+	// TrendListTrendingPlays is the TrendingPlays list
+	TrendListTrendingPlays TrendList = "plays_delta"
+)
+
+// TrendPoint is the position of an item in one interval of a trend list
+type TrendPoint struct {
+	Start       time.Time `json:"start"`
+	Rank        int       `json:"rank"`
+	Delta       int       `json:"delta"`
+	Appearances int       `json:"appearances"`
+}
+
+// TrendSeries is the history of one item in a trend list, the points are sorted by the start date
+// and the intervals that the item is not in the list are not included
+type TrendSeries struct {
+	ID     int64        `json:"id"`
+	Name   string       `json:"name"`
+	Points []TrendPoint `json:"points"`
+}
+
+func alignTrendStart(interval TrendInterval, t time.Time) (time.Time, error) {
+	switch interval {
+	case TrendIntervalWeek:
+		t = getPreviousDay(t, time.Monday)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	case TrendIntervalMonth:
+		return getStartOfTheMonth(t), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid interval: %q", interval)
+}
+
+func (bgg *BGG) trendList(ctx context.Context, list TrendList, interval TrendInterval, start time.Time) ([]TrendOutput, error) {
+	switch list {
+	case TrendListBestSellers:
+		if interval != TrendIntervalWeek {
+			return nil, fmt.Errorf("invalid interval for best sellers: %q", interval)
+		}
+		return bgg.BestSellers(ctx, start)
+	case TrendListMostPlays:
+		return bgg.MostPlays(ctx, interval, start)
+	case TrendListTrendingPlays:
+		return bgg.TrendingPlays(ctx, interval, start)
+	}
+
+	return nil, fmt.Errorf("invalid trend list: %q", list)
+}
+
+// TrendsHistory walks backwards from the interval containing the to date to the interval
+// containing the from date, one request per interval (so the limiter is respected), and
+// returns the series of each item that appeared in the list. The result is sorted by the best
+// rank of each item.
+func (bgg *BGG) TrendsHistory(ctx context.Context, list TrendList, interval TrendInterval, from, to time.Time) ([]TrendSeries, error) {
+	if to.Before(from) {
+		return nil, fmt.Errorf("invalid range: %s is before %s", to, from)
+	}
+
+	first, err := alignTrendStart(interval, from)
+	if err != nil {
+		return nil, err
+	}
+	start, _ := alignTrendStart(interval, to)
+
+	byID := make(map[int64]*TrendSeries)
+	for ; !start.Before(first); start = previousTrendStart(interval, start) {
+		items, err := bgg.trendList(ctx, list, interval, start)
+		if err != nil {
+			return nil, fmt.Errorf("get %s trends for %s failed: %w", list, start.Format(bggTimeFormat), err)
+		}
+
+		for i := range items {
+			series, ok := byID[items[i].ID]
+			if !ok {
+				series = &TrendSeries{ID: items[i].ID, Name: items[i].Name}
+				byID[items[i].ID] = series
+			}
+			series.Points = append(series.Points, TrendPoint{
+				Start:       start,
+				Rank:        items[i].Rank,
+				Delta:       items[i].Delta,
+				Appearances: items[i].Appearances,
+			})
+		}
+	}
+
+	result := make([]TrendSeries, 0, len(byID))
+	for _, series := range byID {
+		// The points are collected backwards
+		for i, j := 0, len(series.Points)-1; i < j; i, j = i+1, j-1 {
+			series.Points[i], series.Points[j] = series.Points[j], series.Points[i]
+		}
+		result = append(result, *series)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		ri, rj := result[i].bestRank(), result[j].bestRank()
+		if ri != rj {
+			return ri < rj
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+func previousTrendStart(interval TrendInterval, t time.Time) time.Time {
+	if interval == TrendIntervalMonth {
+		return t.AddDate(0, -1, 0)
+	}
+
+	return t.AddDate(0, 0, -7)
+}
+
+func (ts *TrendSeries) bestRank() int {
+	best := 0
+	for i := range ts.Points {
+		if r := ts.Points[i].Rank; r > 0 && (best == 0 || r < best) {
+			best = r
+		}
+	}
+	if best == 0 {
+		// Not ranked items go last
+		return int(^uint(0) >> 1)
+	}
+
+	return best
+}
@@ -100,7 +100,7 @@ func (bgg *BGG) GetUser(ctx context.Context, username string) (*User, error) {
 	defer resp.Body.Close()
 
 	var result userResponse
-	if err = decode(resp.Body, &result); err != nil {
+	if err = bgg.decode(EndpointUser, resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("XML decoding failed: %w", err)
 	}
 